@@ -3,14 +3,16 @@ package main
 import (
 	"context"
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"os"
 	"slash10k/pkg/command"
 	"slash10k/pkg/config"
-	"slash10k/pkg/convert/fromdb"
 	"slash10k/pkg/db"
 	"slash10k/pkg/domain"
+	"slash10k/pkg/rest"
+	"slash10k/pkg/scheduler"
 	"strings"
 
 	"github.com/diamondburned/arikawa/v3/api"
@@ -19,18 +21,463 @@ import (
 	"github.com/diamondburned/arikawa/v3/state"
 )
 
+var configKeyChoices = []discord.StringChoice{
+	{
+		Name:              "emoji (reaction to register)",
+		NameLocalizations: german("emoji (Reaktion zum Registrieren)"),
+		Value:             command.ConfigKeyEmoji,
+	},
+	{
+		Name:              "title (title of the board, {amount} becomes the amount)",
+		NameLocalizations: german("title (Titel der Tafel, {amount} wird zum Betrag)"),
+		Value:             command.ConfigKeyTitle,
+	},
+	{
+		Name:              "color (color of the board, e.g. #F1C40F)",
+		NameLocalizations: german("color (Farbe der Tafel, z.B. #F1C40F)"),
+		Value:             command.ConfigKeyColor,
+	},
+	{
+		Name:              "sort (order of the board, name or debt)",
+		NameLocalizations: german("sort (Sortierung der Tafel, name oder debt)"),
+		Value:             command.ConfigKeySort,
+	},
+	{
+		Name:              "amount (amount of a penalty)",
+		NameLocalizations: german("amount (Betrag einer Strafe)"),
+		Value:             command.ConfigKeyAmount,
+	},
+	{
+		Name:              "language (de, en or auto for the language of each user)",
+		NameLocalizations: german("language (de, en oder auto für die Sprache jedes Nutzers)"),
+		Value:             command.ConfigKeyLanguage,
+	},
+	{
+		Name:              "reminder_day (weekday debtors are reminded on, or off)",
+		NameLocalizations: german("reminder_day (Wochentag, an dem Schuldner erinnert werden, oder off)"),
+		Value:             command.ConfigKeyReminderDay,
+	},
+	{
+		Name:              "reminder_time (time of the reminders, e.g. 19:30)",
+		NameLocalizations: german("reminder_time (Uhrzeit der Erinnerungen, z.B. 19:30)"),
+		Value:             command.ConfigKeyReminderTime,
+	},
+	{
+		Name:              "reminder_threshold (debt that has to be exceeded to be reminded)",
+		NameLocalizations: german("reminder_threshold (Schulden, ab denen erinnert wird)"),
+		Value:             command.ConfigKeyReminderThreshold,
+	},
+	{
+		Name:              "summary_day (weekday the weekly summary is posted on, or off)",
+		NameLocalizations: german("summary_day (Wochentag, an dem der Wochenrückblick gepostet wird, oder off)"),
+		Value:             command.ConfigKeySummaryDay,
+	},
+	{
+		Name:              "summary_time (time of the weekly summary, e.g. 19:30)",
+		NameLocalizations: german("summary_time (Uhrzeit des Wochenrückblicks, z.B. 19:30)"),
+		Value:             command.ConfigKeySummaryTime,
+	},
+	{
+		Name:              "timezone (timezone of the schedules, e.g. Europe/Berlin)",
+		NameLocalizations: german("timezone (Zeitzone der Zeitpläne, z.B. Europe/Berlin)"),
+		Value:             command.ConfigKeyTimezone,
+	},
+}
+
 var commands = []api.CreateCommandData{
 	{
-		Name: "10kup", Description: "Setze den Channel in dem der Bot aktiv sein soll", Options: discord.CommandOptions{
+		Name:                     "10kup",
+		Description:              "Set the channel the bot is active in",
+		DescriptionLocalizations: german("Setze den Channel, in dem der Bot aktiv sein soll"),
+		Options: discord.CommandOptions{
 			&discord.ChannelOption{
-				OptionName:  "channel_id",
-				Description: "Channel, in dem der Bot aktiv sein soll",
-				Required:    true,
+				OptionName:               "channel_id",
+				OptionNameLocalizations:  german("channel"),
+				Description:              "Channel the bot is active in",
+				DescriptionLocalizations: german("Channel, in dem der Bot aktiv sein soll"),
+				Required:                 true,
+			},
+		},
+	},
+	{
+		Name:                     "10kconfig",
+		Description:              "Settings of the guild",
+		DescriptionLocalizations: german("Einstellungen der Gilde"),
+		Options: discord.CommandOptions{
+			&discord.SubcommandOption{
+				OptionName:               "show",
+				OptionNameLocalizations:  german("anzeigen"),
+				Description:              "Show the settings",
+				DescriptionLocalizations: german("Zeige die Einstellungen"),
+			},
+			&discord.SubcommandOption{
+				OptionName:               "set",
+				OptionNameLocalizations:  german("ändern"),
+				Description:              "Change a setting",
+				DescriptionLocalizations: german("Ändere eine Einstellung"),
+				Options: []discord.CommandOptionValue{
+					&discord.StringOption{
+						OptionName:               "key",
+						OptionNameLocalizations:  german("einstellung"),
+						Description:              "Setting to change",
+						DescriptionLocalizations: german("Einstellung, die geändert werden soll"),
+						Required:                 true,
+						Choices:                  configKeyChoices,
+					},
+					&discord.StringOption{
+						OptionName:               "value",
+						OptionNameLocalizations:  german("wert"),
+						Description:              "New value of the setting",
+						DescriptionLocalizations: german("Neuer Wert der Einstellung"),
+						Required:                 true,
+					},
+				},
+			},
+			&discord.SubcommandOption{
+				OptionName:               "reset",
+				OptionNameLocalizations:  german("zurücksetzen"),
+				Description:              "Reset a setting, all settings if none is given",
+				DescriptionLocalizations: german("Setze eine Einstellung zurück, ohne Angabe alle"),
+				Options: []discord.CommandOptionValue{
+					&discord.StringOption{
+						OptionName:               "key",
+						OptionNameLocalizations:  german("einstellung"),
+						Description:              "Setting to reset",
+						DescriptionLocalizations: german("Einstellung, die zurückgesetzt werden soll"),
+						Choices:                  configKeyChoices,
+					},
+				},
+			},
+		},
+	},
+	{
+		Name:              command.ContextMenuAddPenalty,
+		NameLocalizations: german("10k geben"),
+		Type:              discord.UserCommand,
+	},
+	{
+		Name:              command.ContextMenuAddPenalty,
+		NameLocalizations: german("10k geben"),
+		Type:              discord.MessageCommand,
+	},
+	{
+		Name:                     "10k",
+		Description:              "Debts in the guild bank",
+		DescriptionLocalizations: german("Schulden in der Gildenbank"),
+		Options: discord.CommandOptions{
+			&discord.SubcommandOption{
+				OptionName:               "add",
+				OptionNameLocalizations:  german("strafe"),
+				Description:              "Give a player a penalty",
+				DescriptionLocalizations: german("Gib einem Spieler eine Strafe"),
+				Options: []discord.CommandOptionValue{
+					&discord.StringOption{
+						OptionName:               "player",
+						OptionNameLocalizations:  german("spieler"),
+						Description:              "Player that gets the penalty",
+						DescriptionLocalizations: german("Spieler, der die Strafe bekommt"),
+						Required:                 true,
+						Autocomplete:             true,
+					},
+					&discord.StringOption{
+						OptionName:              "amount",
+						OptionNameLocalizations: german("betrag"),
+						Description:             "Amount of the penalty, e.g. 10k or 5000, otherwise the amount of the guild",
+						DescriptionLocalizations: german(
+							"Betrag der Strafe, z.B. 10k oder 5000, sonst der Betrag der Gilde",
+						),
+					},
+					&discord.StringOption{
+						OptionName:               "reason",
+						OptionNameLocalizations:  german("grund"),
+						Description:              "Reason of the penalty",
+						DescriptionLocalizations: german("Grund der Strafe"),
+						MaxLength:                option.NewInt(command.MaxPenaltyReasonLength),
+					},
+				},
+			},
+			&discord.SubcommandOption{
+				OptionName:               "history",
+				OptionNameLocalizations:  german("verlauf"),
+				Description:              "Show the latest changes of the debts",
+				DescriptionLocalizations: german("Zeige die letzten Änderungen an den Schulden"),
+				Options: []discord.CommandOptionValue{
+					&discord.UserOption{
+						OptionName:               "player",
+						OptionNameLocalizations:  german("spieler"),
+						Description:              "Player whose changes are shown",
+						DescriptionLocalizations: german("Spieler, dessen Änderungen angezeigt werden sollen"),
+					},
+					&discord.StringOption{
+						OptionName:               "category",
+						OptionNameLocalizations:  german("kategorie"),
+						Description:              "Only show penalties of this category",
+						DescriptionLocalizations: german("Zeige nur Strafen dieser Kategorie"),
+						Autocomplete:             true,
+					},
+				},
+			},
+			&discord.SubcommandOption{
+				OptionName:               "summary",
+				OptionNameLocalizations:  german("rückblick"),
+				Description:              "Summarize the changes of the debts in a week",
+				DescriptionLocalizations: german("Fasse die Änderungen an den Schulden einer Woche zusammen"),
+				Options: []discord.CommandOptionValue{
+					&discord.IntegerOption{
+						OptionName:               "week",
+						OptionNameLocalizations:  german("woche"),
+						Description:              "Weeks to go back, 0 is the last seven days",
+						DescriptionLocalizations: german("Wochen, die zurückgegangen wird, 0 sind die letzten sieben Tage"),
+						Min:                      option.NewInt(0),
+					},
+				},
+			},
+			&discord.SubcommandOption{
+				OptionName:               "correct",
+				OptionNameLocalizations:  german("korrigieren"),
+				Description:              "Correct the debt of a player",
+				DescriptionLocalizations: german("Korrigiere die Schulden eines Spielers"),
+				Options: []discord.CommandOptionValue{
+					&discord.UserOption{
+						OptionName:               "player",
+						OptionNameLocalizations:  german("spieler"),
+						Description:              "Player whose debt is corrected",
+						DescriptionLocalizations: german("Spieler, dessen Schulden korrigiert werden sollen"),
+						Required:                 true,
+					},
+					&discord.IntegerOption{
+						OptionName:               "amount",
+						OptionNameLocalizations:  german("betrag"),
+						Description:              "New amount of the debt",
+						DescriptionLocalizations: german("Neuer Betrag der Schulden"),
+						Required:                 true,
+					},
+				},
+			},
+			&discord.SubcommandOption{
+				OptionName:               "export",
+				OptionNameLocalizations:  german("export"),
+				Description:              "Export the debts or the journal as a file",
+				DescriptionLocalizations: german("Exportiere die Schulden oder das Journal als Datei"),
+				Options: []discord.CommandOptionValue{
+					&discord.StringOption{
+						OptionName:               "format",
+						OptionNameLocalizations:  german("format"),
+						Description:              "Format of the file",
+						DescriptionLocalizations: german("Format der Datei"),
+						Required:                 true,
+						Choices: []discord.StringChoice{
+							{Name: "csv", Value: command.ExportFormatCsv},
+							{Name: "json", Value: command.ExportFormatJson},
+						},
+					},
+					&discord.StringOption{
+						OptionName:               "scope",
+						OptionNameLocalizations:  german("umfang"),
+						Description:              "What to export",
+						DescriptionLocalizations: german("Was exportiert werden soll"),
+						Required:                 true,
+						Choices: []discord.StringChoice{
+							{
+								Name:              "balances",
+								NameLocalizations: german("schulden"),
+								Value:             command.ExportScopeBalances,
+							},
+							{
+								Name:              "journal",
+								NameLocalizations: german("journal"),
+								Value:             command.ExportScopeJournal,
+							},
+						},
+					},
+					&discord.StringOption{
+						OptionName:               "from",
+						OptionNameLocalizations:  german("von"),
+						Description:              "First day of the journal, e.g. 2024-03-01",
+						DescriptionLocalizations: german("Erster Tag des Journals, z.B. 2024-03-01"),
+					},
+					&discord.StringOption{
+						OptionName:               "to",
+						OptionNameLocalizations:  german("bis"),
+						Description:              "Last day of the journal, e.g. 2024-03-31",
+						DescriptionLocalizations: german("Letzter Tag des Journals, z.B. 2024-03-31"),
+					},
+				},
+			},
+			&discord.SubcommandOption{
+				OptionName:               "import",
+				OptionNameLocalizations:  german("import"),
+				Description:              "Set the debts from a CSV file of discord id or name, balance and note",
+				DescriptionLocalizations: german("Setze die Schulden aus einer CSV-Datei mit Discord-ID oder Name, Betrag und Notiz"),
+				Options: []discord.CommandOptionValue{
+					&discord.AttachmentOption{
+						OptionName:               "file",
+						OptionNameLocalizations:  german("datei"),
+						Description:              "CSV file, the changes are shown before they are applied",
+						DescriptionLocalizations: german("CSV-Datei, die Änderungen werden vor dem Anwenden angezeigt"),
+						Required:                 true,
+					},
+				},
+			},
+			&discord.SubcommandGroupOption{
+				OptionName:               "reminders",
+				OptionNameLocalizations:  german("erinnerungen"),
+				Description:              "Turn the reminders of your debts on or off",
+				DescriptionLocalizations: german("Schalte die Erinnerungen an deine Schulden an oder aus"),
+				Subcommands: []*discord.SubcommandOption{
+					{
+						OptionName:               "on",
+						OptionNameLocalizations:  german("an"),
+						Description:              "Remind me of my debts",
+						DescriptionLocalizations: german("Erinnere mich an meine Schulden"),
+					},
+					{
+						OptionName:               "off",
+						OptionNameLocalizations:  german("aus"),
+						Description:              "Do not remind me of my debts",
+						DescriptionLocalizations: german("Erinnere mich nicht an meine Schulden"),
+					},
+				},
+			},
+			&discord.SubcommandGroupOption{
+				OptionName:               "category",
+				OptionNameLocalizations:  german("kategorie"),
+				Description:              "Manage the categories of penalties",
+				DescriptionLocalizations: german("Verwalte die Kategorien von Strafen"),
+				Subcommands: []*discord.SubcommandOption{
+					{
+						OptionName:               "add",
+						OptionNameLocalizations:  german("hinzufügen"),
+						Description:              "Add a category",
+						DescriptionLocalizations: german("Füge eine Kategorie hinzu"),
+						Options: []discord.CommandOptionValue{
+							&discord.StringOption{
+								OptionName:               "name",
+								Description:              "Name of the category, e.g. late for the raid",
+								DescriptionLocalizations: german("Name der Kategorie, z.B. zu spät zum Raid"),
+								Required:                 true,
+								MaxLength:                option.NewInt(domain.MaxPenaltyCategoryNameLength),
+							},
+							&discord.StringOption{
+								OptionName:               "amount",
+								OptionNameLocalizations:  german("betrag"),
+								Description:              "Amount of a penalty of this category, e.g. 10k or 5000",
+								DescriptionLocalizations: german("Betrag einer Strafe dieser Kategorie, z.B. 10k oder 5000"),
+								Required:                 true,
+							},
+						},
+					},
+					{
+						OptionName:               "remove",
+						OptionNameLocalizations:  german("entfernen"),
+						Description:              "Remove a category",
+						DescriptionLocalizations: german("Entferne eine Kategorie"),
+						Options: []discord.CommandOptionValue{
+							&discord.StringOption{
+								OptionName:               "name",
+								Description:              "Name of the category",
+								DescriptionLocalizations: german("Name der Kategorie"),
+								Required:                 true,
+								Autocomplete:             true,
+							},
+						},
+					},
+					{
+						OptionName:               "list",
+						OptionNameLocalizations:  german("liste"),
+						Description:              "Show all categories",
+						DescriptionLocalizations: german("Zeige alle Kategorien"),
+					},
+				},
+			},
+			&discord.SubcommandGroupOption{
+				OptionName:               "api",
+				OptionNameLocalizations:  german("api"),
+				Description:              "Manage the token of the read-only HTTP API",
+				DescriptionLocalizations: german("Verwalte den Token der lesenden HTTP-API"),
+				Subcommands: []*discord.SubcommandOption{
+					{
+						OptionName:               "token",
+						OptionNameLocalizations:  german("token"),
+						Description:              "Create a new token, the previous one stops working",
+						DescriptionLocalizations: german("Erstelle einen neuen Token, der vorherige hört auf zu funktionieren"),
+					},
+					{
+						OptionName:               "revoke",
+						OptionNameLocalizations:  german("widerrufen"),
+						Description:              "Revoke the token",
+						DescriptionLocalizations: german("Widerrufe den Token"),
+					},
+				},
+			},
+			&discord.SubcommandOption{
+				OptionName:               "penalty",
+				OptionNameLocalizations:  german("strafbetrag"),
+				Description:              "Set the amount of a penalty in this guild",
+				DescriptionLocalizations: german("Setze den Betrag einer Strafe für diese Gilde"),
+				Options: []discord.CommandOptionValue{
+					&discord.StringOption{
+						OptionName:               "amount",
+						OptionNameLocalizations:  german("betrag"),
+						Description:              "Amount of a penalty, e.g. 10k or 5000",
+						DescriptionLocalizations: german("Betrag einer Strafe, z.B. 10k oder 5000"),
+						Required:                 true,
+					},
+				},
+			},
+			&discord.SubcommandOption{
+				OptionName:               "delete",
+				OptionNameLocalizations:  german("löschen"),
+				Description:              "Delete a player for good, including debt and history",
+				DescriptionLocalizations: german("Lösche einen Spieler endgültig, samt Schulden und Verlauf"),
+				Options: []discord.CommandOptionValue{
+					&discord.UserOption{
+						OptionName:               "player",
+						OptionNameLocalizations:  german("spieler"),
+						Description:              "Player to delete",
+						DescriptionLocalizations: german("Spieler, der gelöscht werden soll"),
+						Required:                 true,
+					},
+				},
+			},
+			&discord.SubcommandOption{
+				OptionName:               "adminrole",
+				OptionNameLocalizations:  german("adminrolle"),
+				Description:              "Set the role whose members may manage the bot",
+				DescriptionLocalizations: german("Setze die Rolle, deren Mitglieder den Bot verwalten dürfen"),
+				Options: []discord.CommandOptionValue{
+					&discord.RoleOption{
+						OptionName:               "role",
+						OptionNameLocalizations:  german("rolle"),
+						Description:              "Admin role, it is removed if none is given",
+						DescriptionLocalizations: german("Admin-Rolle, ohne Angabe wird sie entfernt"),
+					},
+				},
+			},
+			&discord.SubcommandOption{
+				OptionName:               "treasurer",
+				OptionNameLocalizations:  german("schatzmeister"),
+				Description:              "Set the channel in which admins approve reported payments",
+				DescriptionLocalizations: german("Setze den Channel, in dem Admins gemeldete Zahlungen freigeben"),
+				Options: []discord.CommandOptionValue{
+					&discord.ChannelOption{
+						OptionName:               "channel",
+						OptionNameLocalizations:  german("channel"),
+						Description:              "Treasurer channel, payments are applied right away if none is given",
+						DescriptionLocalizations: german("Schatzmeister-Channel, ohne Angabe werden Zahlungen sofort verbucht"),
+						ChannelTypes:             []discord.ChannelType{discord.GuildText},
+					},
+				},
 			},
 		},
 	},
 }
 
+// german translates the name or description of a command, English is the default of Discord.
+func german(text string) discord.StringLocales {
+	return discord.StringLocales{discord.German: text}
+}
+
 func main() {
 	setupLogger()
 
@@ -43,7 +490,12 @@ func main() {
 
 	s := state.New("Bot " + token)
 	s.AddInteractionHandler(r)
-	s.AddIntents(gateway.IntentGuildMessageReactions)
+	s.AddIntents(
+		gateway.IntentGuilds |
+			gateway.IntentGuildMembers |
+			gateway.IntentGuildMessages |
+			gateway.IntentGuildMessageReactions,
+	)
 
 	cfg, err := config.NewConfigFromEnv()
 	if err != nil {
@@ -53,23 +505,106 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("could not migrate database")
 	}
-	d := db.NewDatabase(cfg.ConnectionString())
-
-	conn, err := d.Connect(context.Background())
+	d, err := db.NewDatabase(
+		context.Background(),
+		cfg.ConnectionString(),
+		db.WithMaxConns(cfg.PoolMaxConns),
+		db.WithMinConns(cfg.PoolMinConns),
+		db.WithMaxConnIdleTime(cfg.PoolMaxConnIdleTime),
+	)
 	if err != nil {
-		log.Fatal().Err(err).Msg("could not connect to database")
+		log.Fatal().Err(err).Msg("could not create database connection pool")
 	}
-	botSetups, err := conn.Queries().GetAllBotSetups(context.Background())
+	defer d.Close()
+
+	service := domain.WithCachedGuildSettings(domain.NewSlashTenK(d))
+
+	botSetups, err := service.GetAllBotSetups(context.Background())
 	if err != nil {
 		log.Fatal().Err(err).Msg("could not get bot setups")
 	}
-	messageLookup := domain.NewMessageLookup(fromdb.FromBotSetups(botSetups))
+	messageLookup := domain.NewMessageLookup(botSetups)
 
-	service := domain.NewSlashTenK(d)
+	authorizer := command.NewAuthorizer(s, service, cfg.BotOwnerIds)
 
-	command.RegisterDiscordHandlers(s, service, messageLookup)
+	command.RegisterDiscordHandlers(s, service, messageLookup, authorizer)
+	go command.PurgeOrphanedGuilds(context.Background(), service, messageLookup, cfg.GuildRetentionPeriod)
+	go scheduler.New(
+		scheduler.DefaultInterval,
+		command.RemindDebtors(s, service),
+		command.PostWeeklySummaries(s, service),
+	).Run(context.Background())
+	if cfg.ApiAddress != "" {
+		go func() {
+			if err := rest.ListenAndServe(context.Background(), cfg.ApiAddress, service); err != nil {
+				log.Error().Msgf("cannot serve api: %s", err)
+			}
+		}()
+	}
 
-	r.AddFunc("10kup", command.SetChannel(s, service, messageLookup))
+	r.With(command.RequireAdmin(authorizer)).AddFunc("10kup", command.SetChannel(s, service, messageLookup))
+	r.AddFunc(command.ContextMenuAddPenalty, command.AddPenaltyFromContextMenu(service))
+	r.Sub(
+		"10kconfig", func(r *cmdroute.Router) {
+			r.Use(command.RequireAdmin(authorizer))
+			r.AddFunc("show", command.ShowConfig(service))
+			r.AddFunc("set", command.SetConfig(s, service))
+			r.AddFunc("reset", command.ResetConfig(s, service))
+		},
+	)
+	r.Sub(
+		"10k", func(r *cmdroute.Router) {
+			r.AddFunc("add", command.AddPenalty(service))
+			r.AddAutocompleterFunc("add", command.AutocompletePlayer(service))
+			r.AddFunc("history", command.History(service))
+			r.AddAutocompleterFunc("history", command.AutocompletePenaltyCategory(service))
+			r.AddFunc("summary", command.Summary(service))
+			r.Group(
+				func(r *cmdroute.Router) {
+					r.Use(command.RequireAdmin(authorizer))
+					r.AddFunc("correct", command.CorrectDebt(s, service))
+					r.AddFunc("penalty", command.SetPenaltyAmount(s, service))
+					r.AddFunc("adminrole", command.SetAdminRole(service))
+					r.AddFunc("treasurer", command.SetTreasurerChannel(service))
+					r.AddFunc("delete", command.DeletePlayer(s, service))
+					r.AddFunc("export", command.Export(service))
+					r.With(
+						cmdroute.Deferrable(
+							s, cmdroute.DeferOpts{
+								Flags: discord.EphemeralMessage,
+								Error: func(err error) {
+									log.Error().Msgf("could not follow up on import: %s", err)
+								},
+							},
+						),
+					).AddFunc("import", command.Import(s, service))
+				},
+			)
+			r.Sub(
+				"reminders", func(r *cmdroute.Router) {
+					r.AddFunc("on", command.SetReminders(service, true))
+					r.AddFunc("off", command.SetReminders(service, false))
+				},
+			)
+			r.Sub(
+				"category", func(r *cmdroute.Router) {
+					// autocompleters are only found on the router itself, not in its groups
+					r.Use(command.RequireAdmin(authorizer))
+					r.AddFunc("add", command.AddPenaltyCategory(service))
+					r.AddFunc("remove", command.RemovePenaltyCategory(service))
+					r.AddAutocompleterFunc("remove", command.AutocompletePenaltyCategory(service))
+					r.AddFunc("list", command.ListPenaltyCategories(service))
+				},
+			)
+			r.Sub(
+				"api", func(r *cmdroute.Router) {
+					r.Use(command.RequireAdmin(authorizer))
+					r.AddFunc("token", command.CreateApiToken(service))
+					r.AddFunc("revoke", command.RevokeApiToken(service))
+				},
+			)
+		},
+	)
 
 	if err := cmdroute.OverwriteCommands(s, commands); err != nil {
 		log.Fatal().Msgf("cannot update commands: %s", err)
@@ -10,6 +10,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"os"
 	"slash10k/pkg/domain"
+	"slash10k/pkg/i18n"
 	"slash10k/pkg/models"
 	"slices"
 	"strings"
@@ -17,10 +18,24 @@ import (
 )
 
 const (
-	ComponentIdSelectPlayer          = "SELECT_PLAYER"
-	ComponentPlaceholderSelectPlayer = "Select a player"
-	ComponentIdPaid                  = "PAID"
-	ComponentLabelPaid               = "I paid!"
+	// MaxSelectOptions up to MaxEmbedFields are limits set by Discord.
+	MaxSelectOptions     = 25
+	MaxOptionLabelLength = 100
+	MaxPlaceholderLength = 150
+	MaxEmbedFieldLength  = 1024
+	MaxEmbedFields       = 25
+	// MaxEmbedFieldsLength keeps the fields below the 6000 characters Discord allows for all
+	// embeds of a message, leaving room for title, description and footer.
+	MaxEmbedFieldsLength = 5500
+	// MaxPlayerSelects leaves one of the five action rows of a message for the pay button.
+	MaxPlayerSelects = 4
+	// PendingPaymentMarker precedes the payments of a player that wait for the approval of a treasurer.
+	PendingPaymentMarker = "⏳"
+)
+
+const (
+	ComponentIdSelectPlayer = "SELECT_PLAYER"
+	ComponentIdPay          = "PAID" // id of the former "I paid!" button, kept for existing boards
 )
 
 func updateDebtsMessage(ctx context.Context, state *state.State, service domain.Service, guildId string) {
@@ -39,6 +54,12 @@ func updateDebtsMessage(ctx context.Context, state *state.State, service domain.
 	log.Debug().Msgf("retrieved bot-setup for guild %s", guildId)
 	channelId, messageId := botSetupToDiscordTypes(*botSetup)
 
+	settings, err := service.GetGuildSettings(ctx, guildId)
+	if err != nil {
+		log.Error().Msgf("cannot get guild settings: %s", err)
+		return
+	}
+
 	if channelId == discord.NullChannelID || messageId == discord.NullMessageID {
 		log.Error().Msg("channel id or message id is null")
 		return
@@ -47,9 +68,16 @@ func updateDebtsMessage(ctx context.Context, state *state.State, service domain.
 	_, err = state.EditMessageComplex(
 		channelId,
 		messageId,
-		debtsForEditMessage(allPlayers),
+		debtsForEditMessage(allPlayers, *settings, guildLocale(state, *settings)),
 	)
-	if err != nil {
+	if isUnknownMessage(err) {
+		log.Warn().Msgf("debts message of guild %s is missing, restoring it", guildId)
+		err = restoreDebtsMessage(ctx, state, service, *botSetup)
+		if err != nil {
+			log.Error().Msgf("cannot restore debts message: %s", err)
+		}
+		return
+	} else if err != nil {
 		log.Error().Msgf("cannot edit message: %s", err)
 		return
 	}
@@ -68,59 +96,91 @@ func botSetupToDiscordTypes(botSetup models.BotSetup) (discord.ChannelID, discor
 	return discord.ChannelID(channelId), discord.MessageID(messageId)
 }
 
-func debtsForSendMessage(allPlayers []models.Player) api.SendMessageData {
+func debtsForSendMessage(
+	allPlayers []models.Player,
+	settings models.GuildSettings,
+	locale i18n.Locale,
+) api.SendMessageData {
 	return api.SendMessageData{
 		Content:    "",
-		Embeds:     []discord.Embed{transformDebtsToEmbed(allPlayers)},
-		Components: debtsMessageButtonComponents(allPlayers),
+		Embeds:     []discord.Embed{transformDebtsToEmbed(allPlayers, settings, locale)},
+		Components: debtsMessageButtonComponents(allPlayers, locale),
 	}
 }
 
-func debtsForEditMessage(allPlayers []models.Player) api.EditMessageData {
-	buttons := debtsMessageButtonComponents(allPlayers)
+func debtsForEditMessage(
+	allPlayers []models.Player,
+	settings models.GuildSettings,
+	locale i18n.Locale,
+) api.EditMessageData {
+	buttons := debtsMessageButtonComponents(allPlayers, locale)
 	return api.EditMessageData{
 		Content:    option.NewNullableString(""),
-		Embeds:     &[]discord.Embed{transformDebtsToEmbed(allPlayers)},
+		Embeds:     &[]discord.Embed{transformDebtsToEmbed(allPlayers, settings, locale)},
 		Components: &buttons,
 	}
 }
 
-func debtsMessageButtonComponents(allPlayers models.Players) discord.ContainerComponents {
+func debtsMessageButtonComponents(allPlayers models.Players, locale i18n.Locale) discord.ContainerComponents {
 	if len(allPlayers) == 0 {
 		return make(discord.ContainerComponents, 0)
 	}
 
+	payButton := &discord.ButtonComponent{
+		Style:    discord.PrimaryButtonStyle(),
+		CustomID: ComponentIdPay,
+		Label:    i18n.T(locale, i18n.Pay),
+	}
+
 	allPlayers.SortByName()
-	playerNames := make([]discord.SelectOption, len(allPlayers))
-	for i, p := range allPlayers {
-		playerNames[i] = discord.SelectOption{
-			Label: p.Name,
-			Value: p.DiscordId,
+	if len(allPlayers) > MaxPlayerSelects*MaxSelectOptions {
+		return discord.ContainerComponents{
+			&discord.ActionRowComponent{
+				&discord.ButtonComponent{
+					Style:    discord.SecondaryButtonStyle(),
+					CustomID: playerPageComponentId(0),
+					Label:    i18n.T(locale, i18n.SelectPlayer),
+				},
+				payButton,
+			},
 		}
 	}
-	return discord.ContainerComponents{
-		&discord.ActionRowComponent{
-			&discord.StringSelectComponent{
-				Options:     playerNames,
-				CustomID:    ComponentIdSelectPlayer,
-				Placeholder: ComponentPlaceholderSelectPlayer,
-			},
-		},
-		&discord.ActionRowComponent{
-			&discord.ButtonComponent{
-				Style:    discord.PrimaryButtonStyle(),
-				CustomID: ComponentIdPaid,
-				Label:    ComponentLabelPaid,
+
+	components := make(discord.ContainerComponents, 0, MaxPlayerSelects+1)
+	for group := range slices.Chunk(allPlayers, MaxSelectOptions) {
+		placeholder := i18n.T(locale, i18n.SelectPlayer)
+		if len(allPlayers) > MaxSelectOptions {
+			placeholder = i18n.T(locale, i18n.SelectPlayerRange, group[0].Name, group[len(group)-1].Name)
+		}
+		components = append(
+			components, &discord.ActionRowComponent{
+				&discord.StringSelectComponent{
+					Options:     playerSelectOptions(group),
+					CustomID:    discord.ComponentID(fmt.Sprintf("%s||%d", ComponentIdSelectPlayer, len(components))),
+					Placeholder: truncate(placeholder, MaxPlaceholderLength),
+				},
 			},
-		},
+		)
 	}
+	return append(components, &discord.ActionRowComponent{payButton})
 }
 
-func transformDebtsToEmbed(players models.Players) discord.Embed {
-	embed := defaultEmbed()
+func playerSelectOptions(players []models.Player) []discord.SelectOption {
+	options := make([]discord.SelectOption, len(players))
+	for i, p := range players {
+		options[i] = discord.SelectOption{
+			Label: truncate(p.Name, MaxOptionLabelLength),
+			Value: p.DiscordId,
+		}
+	}
+	return options
+}
+
+func transformDebtsToEmbed(players models.Players, settings models.GuildSettings, locale i18n.Locale) discord.Embed {
+	embed := defaultEmbed(settings, locale)
 
 	if len(players) > 0 {
-		players.SortByName()
+		players.Sort(settings.SortOrder)
 		maxLength := len(
 			slices.MaxFunc(
 				players, func(p1, p2 models.Player) int {
@@ -128,18 +188,19 @@ func transformDebtsToEmbed(players models.Players) discord.Embed {
 				},
 			).DiscordName,
 		)
-		debtString := strings.Builder{}
-		debtString.WriteString("```")
-		for _, p := range players {
-			debtString.WriteString(fmt.Sprintf("%-*s %v\n", maxLength, p.Name, p.Debt.Amount))
+		lines := make([]string, len(players))
+		for i, p := range players {
+			lines[i] = fmt.Sprintf("%-*s %v", maxLength, p.Name, p.Debt.Amount)
+			if p.PendingPayment > 0 {
+				lines[i] += fmt.Sprintf(" %s -%v", PendingPaymentMarker, p.PendingPayment)
+			}
+			lines[i] += "\n"
 		}
-		debtString.WriteString("```")
-		embed.Fields = []discord.EmbedField{
-			{
-				Name:   "Spieler",
-				Value:  debtString.String(),
-				Inline: false,
-			},
+		fields, shown := debtsEmbedFields(lines, locale)
+		embed.Fields = fields
+		if shown < len(lines) {
+			// the select menus below the board still reach every player
+			embed.Footer = &discord.EmbedFooter{Text: i18n.T(locale, i18n.BoardMorePlayers, len(lines)-shown)}
 		}
 	}
 	log.Debug().Msgf("transformed %v players to discord embed", len(players))
@@ -147,13 +208,47 @@ func transformDebtsToEmbed(players models.Players) discord.Embed {
 	return embed
 }
 
-func defaultEmbed() discord.Embed {
+// debtsEmbedFields distributes the lines over as many code block fields as needed to stay below
+// Discord's limit for the length of a field, and stops once the whole embed would be too long.
+// It returns the fields and the number of lines that made it into them.
+func debtsEmbedFields(lines []string, locale i18n.Locale) ([]discord.EmbedField, int) {
+	fields := make([]discord.EmbedField, 0)
+	total, shown := 0, 0
+	field := strings.Builder{}
+	longestName := i18n.T(locale, i18n.BoardPlayersContinued, MaxEmbedFields)
+	flush := func() {
+		name := i18n.T(locale, i18n.BoardPlayers)
+		if len(fields) > 0 {
+			name = i18n.T(locale, i18n.BoardPlayersContinued, len(fields)+1)
+		}
+		fields = append(fields, discord.EmbedField{Name: name, Value: "```" + field.String() + "```"})
+		total += len(name) + field.Len() + len("``````")
+		field.Reset()
+	}
+	for _, line := range lines {
+		if field.Len() > 0 && field.Len()+len(line)+len("``````") > MaxEmbedFieldLength {
+			flush()
+		}
+		if len(fields) == MaxEmbedFields ||
+			total+field.Len()+len(line)+len(longestName+"``````") > MaxEmbedFieldsLength {
+			break
+		}
+		field.WriteString(line)
+		shown++
+	}
+	if field.Len() > 0 {
+		flush()
+	}
+	return fields, shown
+}
+
+func defaultEmbed(settings models.GuildSettings, locale i18n.Locale) discord.Embed {
 	version := os.Getenv("VERSION")
 	return discord.Embed{
-		Title:       ":moneybag: 10k in die Gildenbank!",
+		Title:       boardTitle(settings, locale),
 		Type:        discord.NormalEmbed,
 		Description: "[GitHub](https://github.com/torfstack/slash10k) | v" + version,
 		Timestamp:   discord.NowTimestamp(),
-		Color:       discord.Color(0xF1C40F),
+		Color:       discord.Color(settings.BoardColor),
 	}
 }
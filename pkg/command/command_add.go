@@ -0,0 +1,196 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/i18n"
+	"slash10k/pkg/models"
+	"strings"
+)
+
+const (
+	MaxPenaltyReasonLength = 200
+
+	// ContextMenuAddPenalty is the name of both the user and the message command.
+	ContextMenuAddPenalty = "Add 10k"
+	MessageJumpLink       = "https://discord.com/channels/%s/%s/%s"
+)
+
+// penaltyPromptData stores the pending penalty and builds the ephemeral prompt for it. The prompt
+// asks for a category first if the guild has categories and the penalty has no amount yet.
+func penaltyPromptData(
+	ctx context.Context,
+	service domain.Service,
+	player models.Player,
+	pending pendingPenalty,
+	locale i18n.Locale,
+) (*api.InteractionResponseData, error) {
+	u := uuid.NewString()
+	if pending.Amount > 0 {
+		pendingConfirmations.Store(u, pending)
+		return &api.InteractionResponseData{
+			Content:    option.NewNullableString(penaltyPrompt(pending.Amount, "", player.Name, pending.Reason, locale)),
+			Components: confirmOrCancelButtonComponents(player.DiscordId, u, DefaultCategoryId, locale),
+			Flags:      discord.EphemeralMessage,
+		}, nil
+	}
+
+	settings, err := service.GetGuildSettings(ctx, player.GuildId)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := service.GetPenaltyCategories(ctx, player.GuildId)
+	if err != nil {
+		return nil, err
+	}
+	pendingConfirmations.Store(u, pending)
+	if len(categories) > 0 {
+		return &api.InteractionResponseData{
+			Content:    option.NewNullableString(i18n.T(locale, i18n.PenaltyWhich, player.Name)),
+			Components: categorySelectComponents(player.DiscordId, u, *settings, categories, locale),
+			Flags:      discord.EphemeralMessage,
+		}, nil
+	}
+	return &api.InteractionResponseData{
+		Content:    option.NewNullableString(penaltyPrompt(settings.PenaltyAmount, "", player.Name, pending.Reason, locale)),
+		Components: confirmOrCancelButtonComponents(player.DiscordId, u, DefaultCategoryId, locale),
+		Flags:      discord.EphemeralMessage,
+	}, nil
+}
+
+func AddPenalty(service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.CommandData,
+) *api.InteractionResponseData {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		guildId := data.Event.GuildID
+		log.Info().Msgf("add penalty called for guild %s", guildId)
+		locale := interactionLocale(ctx, service, data.Event)
+
+		pending := pendingPenalty{
+			Token:  data.Event.Token,
+			Reason: strings.TrimSpace(data.Options.Find("reason").String()),
+		}
+		if amountOption := data.Options.Find("amount"); amountOption.Value != nil {
+			value := amountOption.String()
+			amount, err := parseAmount(value)
+			if err != nil {
+				log.Warn().Msgf("cannot parse amount: %s", err)
+				return ephemeralMessage(i18n.T(locale, i18n.InvalidAmount, value))
+			}
+			if amount <= 0 {
+				return ephemeralMessage(i18n.T(locale, i18n.AmountNotPositive))
+			}
+			pending.Amount = amount
+		}
+
+		player, err := registeredPlayer(ctx, service, playerIdFromOption(data.Options.Find("player").String()), guildId)
+		if err != nil {
+			return ephemeralMessage(i18n.T(locale, i18n.PlayerNotRegisteredPickSuggested))
+		}
+
+		responseData, err := penaltyPromptData(ctx, service, *player, pending, locale)
+		if err != nil {
+			log.Error().Msgf("cannot prepare penalty prompt: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.PenaltyFailed))
+		}
+		return responseData
+	}
+}
+
+// AddPenaltyFromContextMenu handles the user command, which penalizes the selected member, and the
+// message command, which penalizes the author and keeps a link to the message as reason.
+func AddPenaltyFromContextMenu(service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.CommandData,
+) *api.InteractionResponseData {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		guildId := data.Event.GuildID
+		log.Info().Msgf("add penalty from context menu called for guild %s", guildId)
+		locale := interactionLocale(ctx, service, data.Event)
+
+		pending := pendingPenalty{Token: data.Event.Token}
+		playerId := discord.UserID(data.Data.TargetID)
+		if message, ok := data.Data.Resolved.Messages[data.Data.TargetMessageID()]; ok {
+			if message.Author.Bot {
+				return ephemeralMessage(i18n.T(locale, i18n.PenaltyBot))
+			}
+			playerId = message.Author.ID
+			pending.Reason = fmt.Sprintf(MessageJumpLink, guildId, message.ChannelID, message.ID)
+		}
+
+		player, err := registeredPlayer(ctx, service, playerId.String(), guildId)
+		if err != nil {
+			return ephemeralMessage(i18n.T(locale, i18n.PlayerNotRegistered))
+		}
+
+		responseData, err := penaltyPromptData(ctx, service, *player, pending, locale)
+		if err != nil {
+			log.Error().Msgf("cannot prepare penalty prompt: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.PenaltyFailed))
+		}
+		return responseData
+	}
+}
+
+func AutocompletePlayer(service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.AutocompleteData,
+) api.AutocompleteChoices {
+	return func(ctx context.Context, data cmdroute.AutocompleteData) api.AutocompleteChoices {
+		choices := api.AutocompleteStringChoices{}
+		focused := data.Options.Focused()
+		if focused.Name != "player" {
+			return choices
+		}
+
+		allPlayers, err := service.GetAllPlayers(ctx, data.Event.GuildID.String())
+		if err != nil {
+			log.Error().Msgf("cannot get all players: %s", err)
+			return choices
+		}
+		models.Players(allPlayers).SortByName()
+		typed := strings.ToLower(focused.String())
+		for _, p := range allPlayers {
+			if len(choices) == MaxSelectOptions {
+				break
+			}
+			if strings.Contains(strings.ToLower(p.Name), typed) || strings.Contains(strings.ToLower(p.DiscordName), typed) {
+				choices = append(choices, discord.StringChoice{Name: truncate(p.Name, MaxOptionLabelLength), Value: p.DiscordId})
+			}
+		}
+		return choices
+	}
+}
+
+// registeredPlayer returns the player if it is registered and active in the guild.
+func registeredPlayer(
+	ctx context.Context,
+	service domain.Service,
+	discordId string,
+	guildId discord.GuildID,
+) (*models.Player, error) {
+	player, err := service.GetPlayer(ctx, discordId, guildId.String())
+	if err != nil {
+		return nil, err
+	}
+	if !player.Active {
+		return nil, fmt.Errorf("%w: %s@%s is not active", domain.ErrPlayerDoesNotExist, discordId, guildId)
+	}
+	return player, nil
+}
+
+// playerIdFromOption accepts the discord id chosen from the suggestions as well as a mention.
+func playerIdFromOption(value string) string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(value, "<@")
+	value = strings.TrimPrefix(value, "!")
+	return strings.TrimSuffix(value, ">")
+}
@@ -9,8 +9,9 @@ import (
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/state"
 	"github.com/rs/zerolog/log"
-	"slash10k/pkg/config"
 	"slash10k/pkg/domain"
+	"slash10k/pkg/i18n"
+	"slash10k/pkg/models"
 )
 
 const (
@@ -24,19 +25,14 @@ func SetChannel(state *state.State, service domain.Service, lookup domain.Messag
 	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
 		guildId := data.Event.GuildID
 		log.Info().Msgf("setup channel called for guild %s", guildId)
-
-		if data.Event.SenderID() != config.TorfstackUserId() {
-			log.Error().Msgf("cannot set channel: not torfstack, got %v", data.Event.SenderID())
-			return ephemeralMessage("You are not allowed to set the channel, ask Torfstack!")
-		}
-		log.Debug().Msg("called by correct user 'torfstack'")
+		locale := interactionLocale(ctx, service, data.Event)
 
 		options := data.Options
 		var err error
 		cId, err := options.Find("channel_id").SnowflakeValue()
 		if err != nil {
 			log.Error().Msgf("cannot get channel_id: %s", err)
-			return ephemeralMessage("Could not set channel")
+			return ephemeralMessage(i18n.T(locale, i18n.SetChannelFailed))
 		}
 
 		channelId := discord.ChannelID(cId)
@@ -44,21 +40,27 @@ func SetChannel(state *state.State, service domain.Service, lookup domain.Messag
 		alreadySetup, err := isAlreadySetup(ctx, service, guildId.String())
 		if err != nil {
 			log.Error().Msgf("cannot check if already setup: %s", err)
-			return ephemeralMessage("Could not check if already setup")
+			return ephemeralMessage(i18n.T(locale, i18n.SetupCheckFailed))
 		}
 		if alreadySetup {
 			log.Debug().Msgf("already setup for guild %s, deleting messages and current setup", guildId)
-			err = deleteMessagesAndCurrentSetup(ctx, state, service, guildId.String())
+			err = deleteMessagesAndCurrentSetup(ctx, state, service, lookup, guildId.String())
 			if err != nil {
 				log.Error().Msgf("cannot delete messages and current setup: %s", err)
-				return ephemeralMessage("Could not delete messages and current setup")
+				return ephemeralMessage(i18n.T(locale, i18n.SetupDeleteFailed))
 			}
 		}
 
-		registrationMessage, err := sendRegistrationMessage(state, channelId)
+		settings, err := service.GetGuildSettings(ctx, guildId.String())
+		if err != nil {
+			log.Error().Msgf("cannot get guild settings: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.SettingsFailed))
+		}
+
+		registrationMessage, err := sendRegistrationMessage(state, channelId, *settings)
 		if err != nil {
 			log.Error().Msgf("cannot send registration message: %s", err)
-			return ephemeralMessage("Could not send registration message")
+			return ephemeralMessage(i18n.T(locale, i18n.RegistrationFailed))
 		}
 		registrationMessageId := registrationMessage.ID
 		log.Debug().Msgf("registration message sent with id: %s", registrationMessageId)
@@ -66,7 +68,7 @@ func SetChannel(state *state.State, service domain.Service, lookup domain.Messag
 		debtsMessage, err := sendDebtsMessage(ctx, state, service, guildId.String(), channelId)
 		if err != nil {
 			log.Error().Msgf("cannot send debts message: %s", err)
-			return ephemeralMessage("Could not send debts message")
+			return ephemeralMessage(i18n.T(locale, i18n.DebtsMessageFailed))
 		}
 		debtsMessageId := debtsMessage.ID
 		log.Debug().Msgf("debts message sent with id: %s", debtsMessageId)
@@ -80,21 +82,243 @@ func SetChannel(state *state.State, service domain.Service, lookup domain.Messag
 		)
 		if err != nil {
 			log.Error().Msgf("cannot put bot setup: %s", err)
-			return ephemeralMessage("Could not put bot setup")
+			return ephemeralMessage(i18n.T(locale, i18n.SetupSaveFailed))
+		}
+		// players registered with a previous setup have not reacted to the new message yet
+		err = service.SetAwaitingReactions(ctx, guildId.String(), true)
+		if err != nil {
+			log.Error().Msgf("cannot set awaiting reactions: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.SetupSaveFailed))
 		}
 
 		botSetup, err := service.GetBotSetup(ctx, guildId.String())
 		if err != nil {
 			log.Error().Msgf("cannot get bot setup: %s", err)
-			return ephemeralMessage("Could not get bot setup")
+			return ephemeralMessage(i18n.T(locale, i18n.SetupGetFailed))
 		}
 		lookup.AddSetup(*botSetup)
 
-		return ephemeralMessage("Channel set successfully")
+		return ephemeralMessage(i18n.T(locale, i18n.ChannelSet))
+	}
+}
+
+func CorrectDebt(state *state.State, service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.CommandData,
+) *api.InteractionResponseData {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		guildId := data.Event.GuildID
+		log.Info().Msgf("correct debt called for guild %s", guildId)
+		locale := interactionLocale(ctx, service, data.Event)
+
+		playerId, err := data.Options.Find("player").SnowflakeValue()
+		if err != nil {
+			log.Error().Msgf("cannot get player: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.CorrectFailed))
+		}
+		amount, err := data.Options.Find("amount").IntValue()
+		if err != nil {
+			log.Error().Msgf("cannot get amount: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.CorrectFailed))
+		}
+
+		err = service.CorrectDebt(
+			ctx,
+			discord.UserID(playerId).String(),
+			guildId.String(),
+			amount,
+			fmt.Sprintf(JournalDescriptionCorrection, senderName(data.Event)),
+		)
+		if err != nil {
+			log.Error().Msgf("cannot correct debt: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.CorrectFailed))
+		}
+		scheduleDebtsMessageUpdate(state, service, guildId.String())
+
+		return ephemeralMessage(i18n.T(locale, i18n.Corrected))
+	}
+}
+
+func SetPenaltyAmount(state *state.State, service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.CommandData,
+) *api.InteractionResponseData {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		guildId := data.Event.GuildID
+		log.Info().Msgf("set penalty amount called for guild %s", guildId)
+		locale := interactionLocale(ctx, service, data.Event)
+
+		value := data.Options.Find("amount").String()
+		amount, err := parseAmount(value)
+		if err != nil {
+			log.Warn().Msgf("cannot parse amount: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.InvalidAmount, value))
+		}
+
+		settings, err := service.GetGuildSettings(ctx, guildId.String())
+		if err != nil {
+			log.Error().Msgf("cannot get guild settings: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.PenaltyAmountFailed))
+		}
+		settings.PenaltyAmount = amount
+		err = service.UpdateGuildSettings(ctx, *settings)
+		if errors.Is(err, domain.ErrInvalidGuildSettings) {
+			return ephemeralMessage(i18n.T(locale, i18n.AmountNotPositive))
+		} else if err != nil {
+			log.Error().Msgf("cannot update guild settings: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.PenaltyAmountFailed))
+		}
+		scheduleDebtsMessageUpdate(state, service, guildId.String())
+
+		return ephemeralMessage(i18n.T(locale, i18n.PenaltyAmountSet, formatAmount(amount)))
+	}
+}
+
+func DeletePlayer(state *state.State, service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.CommandData,
+) *api.InteractionResponseData {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		guildId := data.Event.GuildID
+		log.Info().Msgf("delete player called for guild %s", guildId)
+		locale := interactionLocale(ctx, service, data.Event)
+
+		playerId, err := data.Options.Find("player").SnowflakeValue()
+		if err != nil {
+			log.Error().Msgf("cannot get player id: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.DeletePlayerFailed))
+		}
+
+		err = service.DeletePlayer(ctx, playerId.String(), guildId.String())
+		if errors.Is(err, domain.ErrPlayerDoesNotExist) {
+			return ephemeralMessage(i18n.T(locale, i18n.PlayerNotRegistered))
+		} else if err != nil {
+			log.Error().Msgf("cannot delete player: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.DeletePlayerFailed))
+		}
+
+		// the reaction would register the player again, so it has to go as well
+		err = removeRegistrationReaction(ctx, state, service, guildId.String(), discord.UserID(playerId))
+		if err != nil {
+			log.Warn().Msgf("cannot remove registration reaction of deleted player: %s", err)
+		}
+		scheduleDebtsMessageUpdate(state, service, guildId.String())
+
+		return ephemeralMessage(i18n.T(locale, i18n.PlayerDeleted))
+	}
+}
+
+func removeRegistrationReaction(
+	ctx context.Context,
+	s *state.State,
+	service domain.Service,
+	guildId string,
+	userId discord.UserID,
+) error {
+	botSetup, err := service.GetBotSetup(ctx, guildId)
+	if err != nil {
+		return err
+	}
+	settings, err := service.GetGuildSettings(ctx, guildId)
+	if err != nil {
+		return err
+	}
+	registrationMessageId, err := discord.ParseSnowflake(botSetup.RegistrationMessageId)
+	if err != nil {
+		return err
+	}
+	channelId, _ := botSetupToDiscordTypes(*botSetup)
+	return s.DeleteUserReaction(
+		channelId,
+		discord.MessageID(registrationMessageId),
+		userId,
+		discord.APIEmoji(settings.RegistrationEmoji),
+	)
+}
+
+func SetAdminRole(service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.CommandData,
+) *api.InteractionResponseData {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		guildId := data.Event.GuildID
+		log.Info().Msgf("set admin role called for guild %s", guildId)
+		locale := interactionLocale(ctx, service, data.Event)
+
+		roleId := ""
+		if role := data.Options.Find("role"); role.Value != nil {
+			id, err := role.SnowflakeValue()
+			if err != nil {
+				log.Error().Msgf("cannot get role id: %s", err)
+				return ephemeralMessage(i18n.T(locale, i18n.AdminRoleFailed))
+			}
+			roleId = id.String()
+		}
+
+		settings, err := service.GetGuildSettings(ctx, guildId.String())
+		if err != nil {
+			log.Error().Msgf("cannot get guild settings: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.AdminRoleFailed))
+		}
+		settings.AdminRoleId = roleId
+		err = service.UpdateGuildSettings(ctx, *settings)
+		if err != nil {
+			log.Error().Msgf("cannot update guild settings: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.AdminRoleFailed))
+		}
+
+		if roleId == "" {
+			return ephemeralMessage(i18n.T(locale, i18n.AdminRoleRemoved))
+		}
+		return ephemeralMessage(i18n.T(locale, i18n.AdminRoleSet, roleId))
 	}
 }
 
-func deleteMessagesAndCurrentSetup(ctx context.Context, s *state.State, service domain.Service, guildId string) error {
+func SetTreasurerChannel(service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.CommandData,
+) *api.InteractionResponseData {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		guildId := data.Event.GuildID
+		log.Info().Msgf("set treasurer channel called for guild %s", guildId)
+		locale := interactionLocale(ctx, service, data.Event)
+
+		channelId := ""
+		if channel := data.Options.Find("channel"); channel.Value != nil {
+			id, err := channel.SnowflakeValue()
+			if err != nil {
+				log.Error().Msgf("cannot get channel id: %s", err)
+				return ephemeralMessage(i18n.T(locale, i18n.TreasurerFailed))
+			}
+			channelId = id.String()
+		}
+
+		settings, err := service.GetGuildSettings(ctx, guildId.String())
+		if err != nil {
+			log.Error().Msgf("cannot get guild settings: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.TreasurerFailed))
+		}
+		settings.TreasurerChannelId = channelId
+		err = service.UpdateGuildSettings(ctx, *settings)
+		if err != nil {
+			log.Error().Msgf("cannot update guild settings: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.TreasurerFailed))
+		}
+
+		if channelId == "" {
+			return ephemeralMessage(i18n.T(locale, i18n.TreasurerRemoved))
+		}
+		return ephemeralMessage(i18n.T(locale, i18n.TreasurerSet, channelId))
+	}
+}
+
+func deleteMessagesAndCurrentSetup(
+	ctx context.Context,
+	s *state.State,
+	service domain.Service,
+	lookup domain.MessageLookup,
+	guildId string,
+) error {
 	botSetup, err := service.GetBotSetup(ctx, guildId)
 	if err != nil {
 		return errors.New("could not get bot setup")
@@ -111,6 +335,13 @@ func deleteMessagesAndCurrentSetup(ctx context.Context, s *state.State, service
 	if err != nil {
 		return errors.New("could not parse registration message id")
 	}
+	// The setup is removed first, so that deleting its messages is not mistaken for
+	// an accidental deletion that has to be restored.
+	err = service.DeleteBotSetup(ctx, guildId)
+	if err != nil {
+		return fmt.Errorf("could not delete bot setup: %s", err)
+	}
+	lookup.RemoveSetup(*botSetup)
 	// There is s.DeleteMessages, but it does not delete message older than 2 weeks
 	// and requires an additional permission (MANAGE_MESSAGES).
 	err = s.DeleteMessage(
@@ -118,7 +349,7 @@ func deleteMessagesAndCurrentSetup(ctx context.Context, s *state.State, service
 		discord.MessageID(debtsMessageId),
 		DeleteMessageReason,
 	)
-	if err != nil {
+	if err != nil && !isUnknownMessage(err) {
 		return fmt.Errorf("could not delete debts message: %s", err)
 	}
 	err = s.DeleteMessage(
@@ -126,10 +357,10 @@ func deleteMessagesAndCurrentSetup(ctx context.Context, s *state.State, service
 		discord.MessageID(registrationMessageId),
 		DeleteMessageReason,
 	)
-	if err != nil {
+	if err != nil && !isUnknownMessage(err) {
 		return fmt.Errorf("could not delete registration message: %s", err)
 	}
-	return service.DeleteBotSetup(ctx, guildId)
+	return nil
 }
 
 func isAlreadySetup(
@@ -149,8 +380,20 @@ func isAlreadySetup(
 func sendRegistrationMessage(
 	s *state.State,
 	channelId discord.ChannelID,
+	settings models.GuildSettings,
 ) (*discord.Message, error) {
-	m, err := s.SendMessage(channelId, ":moneybag: react to join!")
+	return sendRegistrationMessageWithContent(s, channelId, i18n.RegistrationMessage, settings)
+}
+
+// sendRegistrationMessageWithContent sends the message in the language of the guild, its placeholder
+// is replaced by the registration emoji of the guild.
+func sendRegistrationMessageWithContent(
+	s *state.State,
+	channelId discord.ChannelID,
+	content i18n.Key,
+	settings models.GuildSettings,
+) (*discord.Message, error) {
+	m, err := s.SendMessage(channelId, i18n.T(guildLocale(s, settings), content, emojiMention(settings.RegistrationEmoji)))
 	if err != nil {
 		return nil, errors.New("could not send message")
 	}
@@ -169,7 +412,12 @@ func sendDebtsMessage(
 		return nil, errors.New("could not get all players")
 	}
 
-	m, err := s.SendMessageComplex(channelId, debtsForSendMessage(allPlayers))
+	settings, err := service.GetGuildSettings(ctx, guildId)
+	if err != nil {
+		return nil, errors.New("could not get guild settings")
+	}
+
+	m, err := s.SendMessageComplex(channelId, debtsForSendMessage(allPlayers, *settings, guildLocale(s, *settings)))
 	if err != nil {
 		return nil, errors.New("could not send message")
 	}
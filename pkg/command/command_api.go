@@ -0,0 +1,51 @@
+package command
+
+import (
+	"context"
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/rs/zerolog/log"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/i18n"
+)
+
+// CreateApiToken creates the token of the read-only api for the guild, it replaces the previous token
+// and is only shown to the admin once.
+func CreateApiToken(service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.CommandData,
+) *api.InteractionResponseData {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		guildId := data.Event.GuildID
+		log.Info().Msgf("create api token called for guild %s", guildId)
+		locale := interactionLocale(ctx, service, data.Event)
+
+		token, err := service.CreateApiToken(ctx, guildId.String())
+		if err != nil {
+			log.Error().Msgf("cannot create api token: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.ApiTokenFailed))
+		}
+		return ephemeralMessage(i18n.T(locale, i18n.ApiTokenCreated, token, guildId))
+	}
+}
+
+func RevokeApiToken(service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.CommandData,
+) *api.InteractionResponseData {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		guildId := data.Event.GuildID
+		log.Info().Msgf("revoke api token called for guild %s", guildId)
+		locale := interactionLocale(ctx, service, data.Event)
+
+		revoked, err := service.RevokeApiToken(ctx, guildId.String())
+		if err != nil {
+			log.Error().Msgf("cannot revoke api token: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.ApiTokenFailed))
+		}
+		if !revoked {
+			return ephemeralMessage(i18n.T(locale, i18n.ApiTokenNone))
+		}
+		return ephemeralMessage(i18n.T(locale, i18n.ApiTokenRevoked))
+	}
+}
@@ -0,0 +1,103 @@
+package command
+
+import (
+	"context"
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/rs/zerolog/log"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/i18n"
+	"slices"
+)
+
+// Authorizer decides whether the sender of an interaction may administer the bot in a guild.
+// Admins are the bot owners, members holding the admin role configured for the guild and
+// members with the Manage Guild permission.
+type Authorizer struct {
+	state   *state.State
+	service domain.Service
+	owners  []discord.UserID
+}
+
+func NewAuthorizer(state *state.State, service domain.Service, ownerIds []string) *Authorizer {
+	owners := make([]discord.UserID, 0, len(ownerIds))
+	for _, ownerId := range ownerIds {
+		id, err := discord.ParseSnowflake(ownerId)
+		if err != nil {
+			log.Error().Msgf("cannot parse bot owner id %s: %s", ownerId, err)
+			continue
+		}
+		owners = append(owners, discord.UserID(id))
+	}
+	return &Authorizer{state: state, service: service, owners: owners}
+}
+
+func (a *Authorizer) IsAdmin(ctx context.Context, event *discord.InteractionEvent) bool {
+	senderId := event.SenderID()
+	if slices.Contains(a.owners, senderId) {
+		return true
+	}
+	if event.Member == nil || !event.GuildID.IsValid() {
+		return false
+	}
+
+	settings, err := a.service.GetGuildSettings(ctx, event.GuildID.String())
+	if err != nil {
+		log.Error().Msgf("cannot get guild settings: %s", err)
+		return false
+	}
+	if settings.AdminRoleId != "" &&
+		slices.ContainsFunc(
+			event.Member.RoleIDs, func(id discord.RoleID) bool {
+				return id.String() == settings.AdminRoleId
+			},
+		) {
+		return true
+	}
+
+	permissions, err := a.state.Permissions(event.ChannelID, senderId)
+	if err != nil {
+		log.Error().Msgf("cannot get permissions of %s: %s", senderId, err)
+		return false
+	}
+	return permissions.Has(discord.PermissionManageGuild)
+}
+
+// Admins returns the users that are told about problems with the setup of a guild,
+// the bot owners and the owner of the guild.
+func (a *Authorizer) Admins(guildId discord.GuildID) []discord.UserID {
+	admins := slices.Clone(a.owners)
+	guild, err := a.state.Guild(guildId)
+	if err != nil {
+		log.Error().Msgf("cannot get guild %s: %s", guildId, err)
+		return admins
+	}
+	if !slices.Contains(admins, guild.OwnerID) {
+		admins = append(admins, guild.OwnerID)
+	}
+	return admins
+}
+
+// RequireAdmin is a middleware that rejects commands and components of senders that are
+// not admins. Autocompletion is passed through, as it does not change anything.
+func RequireAdmin(authorizer *Authorizer) cmdroute.Middleware {
+	return func(next cmdroute.InteractionHandler) cmdroute.InteractionHandler {
+		return cmdroute.InteractionHandlerFunc(
+			func(ctx context.Context, event *discord.InteractionEvent) *api.InteractionResponse {
+				if _, ok := event.Data.(*discord.AutocompleteInteraction); ok {
+					return next.HandleInteraction(ctx, event)
+				}
+				if !authorizer.IsAdmin(ctx, event) {
+					log.Warn().Msgf("rejected interaction of non-admin %s in guild %s", event.SenderID(), event.GuildID)
+					return &api.InteractionResponse{
+						Type: api.MessageInteractionWithSource,
+						Data: ephemeralMessage(i18n.T(interactionLocale(ctx, authorizer.service, event), i18n.NotAllowed)),
+					}
+				}
+				return next.HandleInteraction(ctx, event)
+			},
+		)
+	}
+}
@@ -0,0 +1,285 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/rs/zerolog/log"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/i18n"
+	"slash10k/pkg/models"
+	"strconv"
+	"strings"
+)
+
+const (
+	ComponentIdSelectCategory = "SELECT_CATEGORY"
+
+	// DefaultCategoryId selects the guild's default penalty amount instead of a category.
+	DefaultCategoryId int32 = 0
+
+	JournalDescriptionCategoryPenalty = "%s, added by %s"
+	JournalDescriptionReason          = "%s: %s"
+)
+
+// penaltyForCategory resolves the name and amount of the penalty for the given category.
+// The default category has no name and uses the penalty amount of the guild settings.
+func penaltyForCategory(
+	ctx context.Context,
+	service domain.Service,
+	guildId string,
+	categoryId int32,
+) (string, int64, error) {
+	if categoryId == DefaultCategoryId {
+		settings, err := service.GetGuildSettings(ctx, guildId)
+		if err != nil {
+			return "", 0, err
+		}
+		return "", settings.PenaltyAmount, nil
+	}
+	category, err := service.GetPenaltyCategory(ctx, guildId, categoryId)
+	if err != nil {
+		return "", 0, err
+	}
+	return category.Name, category.Amount, nil
+}
+
+func penaltyPrompt(amount int64, category string, playerName string, reason string, locale i18n.Locale) string {
+	prompt := i18n.T(locale, i18n.PenaltyPrompt, formatAmount(amount), playerName)
+	if category != "" {
+		prompt = i18n.T(locale, i18n.PenaltyPromptCategory, formatAmount(amount), category, playerName)
+	}
+	if reason != "" {
+		prompt += "\n" + i18n.T(locale, i18n.PenaltyReason, reason)
+	}
+	return prompt
+}
+
+func penaltyDescription(category string, sender string, reason string) string {
+	description := fmt.Sprintf(domain.JournalDescriptionPenalty, sender)
+	if category != "" {
+		description = fmt.Sprintf(JournalDescriptionCategoryPenalty, category, sender)
+	}
+	if reason != "" {
+		description = fmt.Sprintf(JournalDescriptionReason, description, reason)
+	}
+	return description
+}
+
+func categorySelectComponents(
+	player string,
+	token string,
+	settings models.GuildSettings,
+	categories []models.PenaltyCategory,
+	locale i18n.Locale,
+) *discord.ContainerComponents {
+	options := make([]discord.SelectOption, 0, len(categories)+1)
+	options = append(
+		options, discord.SelectOption{
+			Label: fmt.Sprintf("%s (%s)", i18n.T(locale, i18n.DefaultCategory), formatAmount(settings.PenaltyAmount)),
+			Value: strconv.Itoa(int(DefaultCategoryId)),
+		},
+	)
+	for _, c := range categories {
+		options = append(
+			options, discord.SelectOption{
+				Label: fmt.Sprintf("%s (%s)", c.Name, formatAmount(c.Amount)),
+				Value: strconv.Itoa(int(c.Id)),
+			},
+		)
+	}
+	return &discord.ContainerComponents{
+		&discord.ActionRowComponent{
+			&discord.StringSelectComponent{
+				Options:     options,
+				CustomID:    discord.ComponentID(fmt.Sprintf("%s||%s||%s", ComponentIdSelectCategory, player, token)),
+				Placeholder: i18n.T(locale, i18n.SelectPenalty),
+			},
+		},
+		&discord.ActionRowComponent{
+			&discord.ButtonComponent{
+				Style:    discord.SecondaryButtonStyle(),
+				CustomID: penaltyComponentId(ComponentIdCancelButton, player, token, DefaultCategoryId),
+				Label:    i18n.T(locale, i18n.Cancel),
+			},
+		},
+	}
+}
+
+func respondWithPenaltyConfirmation(
+	ctx context.Context,
+	s *state.State,
+	service domain.Service,
+	event *gateway.InteractionCreateEvent,
+	data *discord.StringSelectInteraction,
+) {
+	if len(data.Values) != 1 {
+		log.Error().Msgf("invalid number of categories selected: %v", len(data.Values))
+		return
+	}
+	locale := interactionLocale(ctx, service, &event.InteractionEvent)
+	components := strings.Split(strings.TrimPrefix(string(data.CustomID), ComponentIdSelectCategory+"||"), "||")
+	if len(components) != 2 {
+		log.Error().Msgf("malformed category select component id: %s", data.CustomID)
+		return
+	}
+	playerId, token := components[0], components[1]
+	pending, ok := pendingConfirmations.Load(token)
+	if !ok {
+		respondWithPromptExpired(s, event, locale)
+		return
+	}
+	categoryId, err := strconv.Atoi(data.Values[0])
+	if err != nil {
+		log.Error().Msgf("could not parse category id: %s", err)
+		return
+	}
+
+	player, err := service.GetPlayer(ctx, playerId, event.GuildID.String())
+	if err != nil {
+		log.Error().Msgf("could not get player: %s", err)
+		return
+	}
+	category, amount, err := penaltyForCategory(ctx, service, event.GuildID.String(), int32(categoryId))
+	if errors.Is(err, domain.ErrPenaltyCategoryDoesNotExist) {
+		respondEphemeral(s, event, i18n.T(locale, i18n.CategoryDeleted))
+		return
+	} else if err != nil {
+		log.Error().Msgf("could not get penalty for category: %s", err)
+		return
+	}
+
+	err = s.RespondInteraction(
+		event.ID, event.Token, api.InteractionResponse{
+			Type: api.UpdateMessage,
+			Data: &api.InteractionResponseData{
+				Content:    option.NewNullableString(penaltyPrompt(amount, category, player.Name, pending.Reason, locale)),
+				Components: confirmOrCancelButtonComponents(player.DiscordId, token, int32(categoryId), locale),
+			},
+		},
+	)
+	if err != nil {
+		log.Error().Msgf("could not respond to interaction: %s", err)
+		return
+	}
+}
+
+func AddPenaltyCategory(service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.CommandData,
+) *api.InteractionResponseData {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		guildId := data.Event.GuildID
+		log.Info().Msgf("add penalty category called for guild %s", guildId)
+		locale := interactionLocale(ctx, service, data.Event)
+
+		name := strings.TrimSpace(data.Options.Find("name").String())
+		value := data.Options.Find("amount").String()
+		amount, err := parseAmount(value)
+		if err != nil {
+			log.Warn().Msgf("cannot parse amount: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.InvalidAmount, value))
+		}
+
+		err = service.AddPenaltyCategory(ctx, guildId.String(), name, amount)
+		switch {
+		case errors.Is(err, domain.ErrInvalidAmount):
+			return ephemeralMessage(i18n.T(locale, i18n.AmountNotPositive))
+		case errors.Is(err, domain.ErrInvalidPenaltyCategoryName):
+			return ephemeralMessage(i18n.T(locale, i18n.CategoryNameInvalid, domain.MaxPenaltyCategoryNameLength))
+		case errors.Is(err, domain.ErrPenaltyCategoryAlreadyExists):
+			return ephemeralMessage(i18n.T(locale, i18n.CategoryExists, name))
+		case errors.Is(err, domain.ErrTooManyPenaltyCategories):
+			return ephemeralMessage(i18n.T(locale, i18n.CategoryLimit, domain.MaxPenaltyCategories))
+		case err != nil:
+			log.Error().Msgf("cannot add penalty category: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.CategoryAddFailed))
+		}
+
+		return ephemeralMessage(i18n.T(locale, i18n.CategoryAdded, name, formatAmount(amount)))
+	}
+}
+
+func RemovePenaltyCategory(service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.CommandData,
+) *api.InteractionResponseData {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		guildId := data.Event.GuildID
+		log.Info().Msgf("remove penalty category called for guild %s", guildId)
+		locale := interactionLocale(ctx, service, data.Event)
+
+		name := data.Options.Find("name").String()
+		err := service.DeletePenaltyCategory(ctx, guildId.String(), name)
+		if errors.Is(err, domain.ErrPenaltyCategoryDoesNotExist) {
+			return ephemeralMessage(i18n.T(locale, i18n.CategoryDoesNotExist, name))
+		} else if err != nil {
+			log.Error().Msgf("cannot remove penalty category: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.CategoryRemoveFailed))
+		}
+
+		return ephemeralMessage(i18n.T(locale, i18n.CategoryRemoved, name))
+	}
+}
+
+func ListPenaltyCategories(service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.CommandData,
+) *api.InteractionResponseData {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		guildId := data.Event.GuildID
+		log.Info().Msgf("list penalty categories called for guild %s", guildId)
+		locale := interactionLocale(ctx, service, data.Event)
+
+		settings, err := service.GetGuildSettings(ctx, guildId.String())
+		if err != nil {
+			log.Error().Msgf("cannot get guild settings: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.CategoryListFailed))
+		}
+		categories, err := service.GetPenaltyCategories(ctx, guildId.String())
+		if err != nil {
+			log.Error().Msgf("cannot get penalty categories: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.CategoryListFailed))
+		}
+
+		list := strings.Builder{}
+		list.WriteString(fmt.Sprintf("%s: %s\n", i18n.T(locale, i18n.DefaultCategory), formatAmount(settings.PenaltyAmount)))
+		for _, c := range categories {
+			list.WriteString(fmt.Sprintf("%s: %s\n", c.Name, formatAmount(c.Amount)))
+		}
+		return ephemeralMessage(list.String())
+	}
+}
+
+// AutocompletePenaltyCategory suggests the penalty categories of the guild for the focused option.
+func AutocompletePenaltyCategory(service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.AutocompleteData,
+) api.AutocompleteChoices {
+	return func(ctx context.Context, data cmdroute.AutocompleteData) api.AutocompleteChoices {
+		choices := api.AutocompleteStringChoices{}
+		focused := data.Options.Focused()
+		if focused.Name != "category" && focused.Name != "name" {
+			return choices
+		}
+
+		categories, err := service.GetPenaltyCategories(ctx, data.Event.GuildID.String())
+		if err != nil {
+			log.Error().Msgf("cannot get penalty categories: %s", err)
+			return choices
+		}
+		typed := strings.ToLower(focused.String())
+		for _, c := range categories {
+			if strings.Contains(strings.ToLower(c.Name), typed) {
+				choices = append(choices, discord.StringChoice{Name: c.Name, Value: c.Name})
+			}
+		}
+		return choices
+	}
+}
@@ -0,0 +1,473 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/rs/zerolog/log"
+	"regexp"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/i18n"
+	"slash10k/pkg/models"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	ConfigKeyEmoji    = "emoji"
+	ConfigKeyTitle    = "title"
+	ConfigKeyColor    = "color"
+	ConfigKeySort     = "sort"
+	ConfigKeyAmount   = "amount"
+	ConfigKeyLanguage = "language"
+
+	ConfigKeyReminderDay       = "reminder_day"
+	ConfigKeyReminderTime      = "reminder_time"
+	ConfigKeyReminderThreshold = "reminder_threshold"
+	ConfigKeySummaryDay        = "summary_day"
+	ConfigKeySummaryTime       = "summary_time"
+	ConfigKeyTimezone          = "timezone"
+
+	// ConfigValueLanguageAuto lets every user see the bot in the language of their client.
+	ConfigValueLanguageAuto = "auto"
+	// ConfigValueScheduleOff turns a schedule of a guild off, e.g. the reminders or the weekly summary.
+	ConfigValueScheduleOff = "off"
+
+	// MaxEmojiLength allows unicode emojis that are made up of several code points, e.g. flags or families.
+	MaxEmojiLength = 32
+)
+
+// ConfigKeys are the guild settings that can be changed with /10kconfig, in the order they are shown.
+var ConfigKeys = []string{
+	ConfigKeyEmoji,
+	ConfigKeyTitle,
+	ConfigKeyColor,
+	ConfigKeySort,
+	ConfigKeyAmount,
+	ConfigKeyLanguage,
+	ConfigKeyReminderDay,
+	ConfigKeyReminderTime,
+	ConfigKeyReminderThreshold,
+	ConfigKeySummaryDay,
+	ConfigKeySummaryTime,
+	ConfigKeyTimezone,
+}
+
+var (
+	customEmojiPattern = regexp.MustCompile(`^<a?:(\w+):(\d+)>$`)
+
+	errInvalidConfigValue = errors.New("invalid config value")
+)
+
+// configValueError is caused by a value that can not be applied to the settings,
+// it describes the problem to the user in their language.
+type configValueError struct {
+	key  i18n.Key
+	args []any
+}
+
+func invalidConfigValue(key i18n.Key, args ...any) error {
+	return configValueError{key: key, args: args}
+}
+
+func (e configValueError) Error() string {
+	return fmt.Sprintf("%s: %s", errInvalidConfigValue, e.Message(i18n.DefaultLocale))
+}
+
+func (e configValueError) Is(target error) bool {
+	return target == errInvalidConfigValue
+}
+
+func (e configValueError) Message(locale i18n.Locale) string {
+	return i18n.T(locale, e.key, e.args...)
+}
+
+func ShowConfig(service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.CommandData,
+) *api.InteractionResponseData {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		guildId := data.Event.GuildID
+		log.Info().Msgf("show config called for guild %s", guildId)
+
+		settings, err := service.GetGuildSettings(ctx, guildId.String())
+		if err != nil {
+			log.Error().Msgf("cannot get guild settings: %s", err)
+			return ephemeralMessage(i18n.T(i18n.FromDiscord(data.Event.Locale), i18n.SettingsFailed))
+		}
+		return ephemeralMessage(formatSettings(*settings, settingsLocale(*settings, data.Event)))
+	}
+}
+
+func SetConfig(state *state.State, service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.CommandData,
+) *api.InteractionResponseData {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		guildId := data.Event.GuildID
+		log.Info().Msgf("set config called for guild %s", guildId)
+
+		key := data.Options.Find("key").String()
+		value := data.Options.Find("value").String()
+		settings, err := service.GetGuildSettings(ctx, guildId.String())
+		if err != nil {
+			log.Error().Msgf("cannot get guild settings: %s", err)
+			return ephemeralMessage(i18n.T(i18n.FromDiscord(data.Event.Locale), i18n.SettingsFailed))
+		}
+		previous := *settings
+
+		err = applySetting(settings, key, value)
+		var valueErr configValueError
+		if errors.As(err, &valueErr) {
+			return ephemeralMessage(valueErr.Message(settingsLocale(previous, data.Event)))
+		} else if err != nil {
+			log.Error().Msgf("cannot apply setting: %s", err)
+			return ephemeralMessage(i18n.T(settingsLocale(previous, data.Event), i18n.SettingsUpdateFailed))
+		}
+		return updateSettings(ctx, state, service, data.Event, previous, *settings)
+	}
+}
+
+func ResetConfig(state *state.State, service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.CommandData,
+) *api.InteractionResponseData {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		guildId := data.Event.GuildID
+		log.Info().Msgf("reset config called for guild %s", guildId)
+
+		key := data.Options.Find("key").String()
+		settings, err := service.GetGuildSettings(ctx, guildId.String())
+		if err != nil {
+			log.Error().Msgf("cannot get guild settings: %s", err)
+			return ephemeralMessage(i18n.T(i18n.FromDiscord(data.Event.Locale), i18n.SettingsFailed))
+		}
+		previous := *settings
+
+		err = resetSetting(settings, key)
+		if err != nil {
+			log.Error().Msgf("cannot reset setting: %s", err)
+			return ephemeralMessage(i18n.T(settingsLocale(previous, data.Event), i18n.SettingsUpdateFailed))
+		}
+		return updateSettings(ctx, state, service, data.Event, previous, *settings)
+	}
+}
+
+func updateSettings(
+	ctx context.Context,
+	s *state.State,
+	service domain.Service,
+	event *discord.InteractionEvent,
+	previous models.GuildSettings,
+	settings models.GuildSettings,
+) *api.InteractionResponseData {
+	// the response already uses a newly chosen language
+	locale := settingsLocale(settings, event)
+	if previous.RegistrationEmoji != settings.RegistrationEmoji {
+		// set first, a reconciliation in between would deactivate everyone who reacted with the previous emoji
+		err := service.SetAwaitingReactions(ctx, settings.GuildId, true)
+		if err != nil {
+			log.Error().Msgf("cannot set awaiting reactions: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.SettingsUpdateFailed))
+		}
+	}
+	err := service.UpdateGuildSettings(ctx, settings)
+	if errors.Is(err, domain.ErrInvalidGuildSettings) {
+		log.Warn().Msgf("invalid guild settings: %s", err)
+		return ephemeralMessage(i18n.T(locale, i18n.SettingsInvalid))
+	} else if err != nil {
+		log.Error().Msgf("cannot update guild settings: %s", err)
+		return ephemeralMessage(i18n.T(locale, i18n.SettingsUpdateFailed))
+	}
+	scheduleDebtsMessageUpdate(s, service, settings.GuildId)
+
+	response := formatSettings(settings, locale)
+	if previous.RegistrationEmoji != settings.RegistrationEmoji || previous.Locale != settings.Locale {
+		err = updateRegistrationMessage(ctx, s, service, settings)
+		if err != nil && !errors.Is(err, domain.ErrBotSetupDoesNotExist) {
+			log.Warn().Msgf("cannot update registration message: %s", err)
+		}
+	}
+	if previous.RegistrationEmoji != settings.RegistrationEmoji {
+		response += "\n" + i18n.T(
+			locale,
+			i18n.SettingsEmojiChanged,
+			emojiMention(settings.RegistrationEmoji),
+			emojiMention(previous.RegistrationEmoji),
+		)
+	}
+	return ephemeralMessage(response)
+}
+
+// updateRegistrationMessage shows the current registration emoji and language in the registration message.
+func updateRegistrationMessage(
+	ctx context.Context,
+	s *state.State,
+	service domain.Service,
+	settings models.GuildSettings,
+) error {
+	botSetup, err := service.GetBotSetup(ctx, settings.GuildId)
+	if err != nil {
+		return err
+	}
+	registrationMessageId, err := discord.ParseSnowflake(botSetup.RegistrationMessageId)
+	if err != nil {
+		return fmt.Errorf("could not parse registration message id: %w", err)
+	}
+	channelId, _ := botSetupToDiscordTypes(*botSetup)
+	_, err = s.EditMessage(
+		channelId,
+		discord.MessageID(registrationMessageId),
+		i18n.T(guildLocale(s, settings), i18n.RegistrationMessage, emojiMention(settings.RegistrationEmoji)),
+	)
+	return err
+}
+
+// applySetting parses the value for the given key into the settings. Errors that are caused by the value
+// wrap errInvalidConfigValue and describe the problem to the user.
+func applySetting(settings *models.GuildSettings, key string, value string) error {
+	value = strings.TrimSpace(value)
+	switch key {
+	case ConfigKeyEmoji:
+		emoji, err := parseEmoji(value)
+		if err != nil {
+			return err
+		}
+		settings.RegistrationEmoji = emoji
+	case ConfigKeyTitle:
+		if utf8.RuneCountInString(value) > domain.MaxBoardTitleLength {
+			return invalidConfigValue(i18n.ConfigTitleTooLong, domain.MaxBoardTitleLength)
+		}
+		settings.BoardTitle = value
+	case ConfigKeyColor:
+		color, err := parseColor(value)
+		if err != nil {
+			return err
+		}
+		settings.BoardColor = color
+	case ConfigKeySort:
+		order := models.SortOrder(strings.ToLower(value))
+		if order != models.SortOrderName && order != models.SortOrderDebt {
+			return invalidConfigValue(i18n.ConfigInvalidSortOrder, value, models.SortOrderName, models.SortOrderDebt)
+		}
+		settings.SortOrder = order
+	case ConfigKeyAmount:
+		amount, err := parseAmount(value)
+		if err != nil {
+			return invalidConfigValue(i18n.InvalidAmount, value)
+		}
+		settings.PenaltyAmount = amount
+	case ConfigKeyLanguage:
+		locale, err := parseLanguage(value)
+		if err != nil {
+			return err
+		}
+		settings.Locale = locale
+	case ConfigKeyReminderDay:
+		weekday, err := parseWeekday(value)
+		if err != nil {
+			return err
+		}
+		settings.ReminderWeekday = weekday
+	case ConfigKeyReminderTime:
+		minute, err := parseTimeOfDay(value)
+		if err != nil {
+			return err
+		}
+		settings.ReminderTime = minute
+	case ConfigKeyReminderThreshold:
+		amount, err := parseAmount(value)
+		if err != nil || amount < 0 {
+			return invalidConfigValue(i18n.ConfigInvalidThreshold, value)
+		}
+		settings.ReminderThreshold = amount
+	case ConfigKeySummaryDay:
+		weekday, err := parseWeekday(value)
+		if err != nil {
+			return err
+		}
+		settings.SummaryWeekday = weekday
+	case ConfigKeySummaryTime:
+		minute, err := parseTimeOfDay(value)
+		if err != nil {
+			return err
+		}
+		settings.SummaryTime = minute
+	case ConfigKeyTimezone:
+		location, err := time.LoadLocation(value)
+		if value == "" || value == "Local" || err != nil {
+			return invalidConfigValue(i18n.ConfigInvalidTimezone, value)
+		}
+		settings.Timezone = location.String()
+	default:
+		return invalidConfigValue(i18n.ConfigUnknownKey, key)
+	}
+	return nil
+}
+
+// resetSetting sets the given key back to its default, all keys are reset if none is given.
+func resetSetting(settings *models.GuildSettings, key string) error {
+	defaults := models.DefaultGuildSettings(settings.GuildId)
+	keys := []string{key}
+	if key == "" {
+		keys = ConfigKeys
+	}
+	for _, k := range keys {
+		switch k {
+		case ConfigKeyEmoji:
+			settings.RegistrationEmoji = defaults.RegistrationEmoji
+		case ConfigKeyTitle:
+			settings.BoardTitle = defaults.BoardTitle
+		case ConfigKeyColor:
+			settings.BoardColor = defaults.BoardColor
+		case ConfigKeySort:
+			settings.SortOrder = defaults.SortOrder
+		case ConfigKeyAmount:
+			settings.PenaltyAmount = defaults.PenaltyAmount
+		case ConfigKeyLanguage:
+			settings.Locale = defaults.Locale
+		case ConfigKeyReminderDay:
+			settings.ReminderWeekday = defaults.ReminderWeekday
+		case ConfigKeyReminderTime:
+			settings.ReminderTime = defaults.ReminderTime
+		case ConfigKeyReminderThreshold:
+			settings.ReminderThreshold = defaults.ReminderThreshold
+		case ConfigKeySummaryDay:
+			settings.SummaryWeekday = defaults.SummaryWeekday
+		case ConfigKeySummaryTime:
+			settings.SummaryTime = defaults.SummaryTime
+		case ConfigKeyTimezone:
+			settings.Timezone = defaults.Timezone
+		default:
+			return invalidConfigValue(i18n.ConfigUnknownKey, k)
+		}
+	}
+	return nil
+}
+
+func formatSettings(settings models.GuildSettings, locale i18n.Locale) string {
+	title := settings.BoardTitle
+	if title == models.DefaultBoardTitle {
+		title = fmt.Sprintf("%s (%s)", i18n.T(locale, i18n.BoardTitle), i18n.T(locale, i18n.SettingsDefault))
+	}
+	language := settings.Locale
+	if language == "" {
+		language = ConfigValueLanguageAuto
+	}
+	return fmt.Sprintf(
+		"%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %s",
+		ConfigKeyEmoji, emojiMention(settings.RegistrationEmoji),
+		ConfigKeyTitle, title,
+		ConfigKeyColor, formatColor(settings.BoardColor),
+		ConfigKeySort, settings.SortOrder,
+		ConfigKeyAmount, formatAmount(settings.PenaltyAmount),
+		ConfigKeyLanguage, language,
+		ConfigKeyReminderDay, formatWeekday(settings.ReminderWeekday),
+		ConfigKeyReminderTime, formatTimeOfDay(settings.ReminderTime),
+		ConfigKeyReminderThreshold, formatAmount(settings.ReminderThreshold),
+		ConfigKeySummaryDay, formatWeekday(settings.SummaryWeekday),
+		ConfigKeySummaryTime, formatTimeOfDay(settings.SummaryTime),
+		ConfigKeyTimezone, settings.Timezone,
+	)
+}
+
+// parseEmoji accepts a unicode emoji or a custom emoji as it is sent in a message, e.g. <:gold:123>.
+// The emoji is returned in the form the API expects for reactions.
+func parseEmoji(value string) (string, error) {
+	if match := customEmojiPattern.FindStringSubmatch(value); match != nil {
+		return match[1] + ":" + match[2], nil
+	}
+	isText := func(r rune) bool {
+		return unicode.IsSpace(r) || r < unicode.MaxASCII && unicode.IsLetter(r)
+	}
+	isSymbol := func(r rune) bool {
+		return unicode.Is(unicode.So, r)
+	}
+	if len(value) > MaxEmojiLength || strings.IndexFunc(value, isText) >= 0 || strings.IndexFunc(value, isSymbol) < 0 {
+		return "", invalidConfigValue(i18n.ConfigInvalidEmoji, value)
+	}
+	return value, nil
+}
+
+// emojiMention returns the emoji in the form it is shown in a message.
+func emojiMention(emoji string) string {
+	if strings.Contains(emoji, ":") {
+		return "<:" + emoji + ">"
+	}
+	return emoji
+}
+
+// parseColor parses a hex color like #F1C40F, the # is optional.
+func parseColor(value string) (int32, error) {
+	hex := strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(value), "#"), "0x")
+	color, err := strconv.ParseInt(hex, 16, 32)
+	if err != nil || len(hex) != 6 {
+		return 0, invalidConfigValue(i18n.ConfigInvalidColor, value)
+	}
+	return int32(color), nil
+}
+
+// parseLanguage accepts the code of a supported locale, auto resets the language of the guild
+// so that the language of the Discord client is used.
+func parseLanguage(value string) (string, error) {
+	value = strings.ToLower(value)
+	if value == ConfigValueLanguageAuto {
+		return "", nil
+	}
+	locale, ok := i18n.Parse(value)
+	if !ok {
+		values := []string{ConfigValueLanguageAuto}
+		for _, l := range i18n.Locales {
+			values = append(values, string(l))
+		}
+		return "", invalidConfigValue(i18n.ConfigInvalidLanguage, value, strings.Join(values, ", "))
+	}
+	return string(locale), nil
+}
+
+// parseWeekday accepts the English name of a weekday, off turns the schedule off.
+func parseWeekday(value string) (time.Weekday, error) {
+	value = strings.ToLower(value)
+	if value == ConfigValueScheduleOff {
+		return models.ScheduleOff, nil
+	}
+	values := []string{ConfigValueScheduleOff}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if value == strings.ToLower(weekday.String()) {
+			return weekday, nil
+		}
+		values = append(values, strings.ToLower(weekday.String()))
+	}
+	return 0, invalidConfigValue(i18n.ConfigInvalidWeekday, value, strings.Join(values, ", "))
+}
+
+func formatWeekday(weekday time.Weekday) string {
+	if weekday == models.ScheduleOff {
+		return ConfigValueScheduleOff
+	}
+	return strings.ToLower(weekday.String())
+}
+
+// parseTimeOfDay parses a time like 19:30 into minutes after midnight.
+func parseTimeOfDay(value string) (int32, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, invalidConfigValue(i18n.ConfigInvalidTime, value)
+	}
+	return int32(t.Hour()*60 + t.Minute()), nil
+}
+
+func formatTimeOfDay(minute int32) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+func formatColor(color int32) string {
+	return fmt.Sprintf("#%06X", color)
+}
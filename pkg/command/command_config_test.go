@@ -0,0 +1,176 @@
+package command
+
+import (
+	"errors"
+	"slash10k/pkg/models"
+	"testing"
+	"time"
+)
+
+func Test_applySetting(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		want    func(models.GuildSettings) bool
+		wantErr bool
+	}{
+		{
+			name:  "unicode emoji",
+			key:   ConfigKeyEmoji,
+			value: "🪙",
+			want:  func(s models.GuildSettings) bool { return s.RegistrationEmoji == "🪙" },
+		},
+		{
+			name:  "custom emoji",
+			key:   ConfigKeyEmoji,
+			value: "<:gold:1234567890>",
+			want:  func(s models.GuildSettings) bool { return s.RegistrationEmoji == "gold:1234567890" },
+		},
+		{
+			name:  "animated custom emoji",
+			key:   ConfigKeyEmoji,
+			value: "<a:gold:1234567890>",
+			want:  func(s models.GuildSettings) bool { return s.RegistrationEmoji == "gold:1234567890" },
+		},
+		{name: "text is no emoji", key: ConfigKeyEmoji, value: "gold", wantErr: true},
+		{name: "number is no emoji", key: ConfigKeyEmoji, value: "10", wantErr: true},
+		{
+			name:  "title",
+			key:   ConfigKeyTitle,
+			value: " {amount} for the bank ",
+			want:  func(s models.GuildSettings) bool { return s.BoardTitle == "{amount} for the bank" },
+		},
+		{
+			name:  "color with hash",
+			key:   ConfigKeyColor,
+			value: "#ff0000",
+			want:  func(s models.GuildSettings) bool { return s.BoardColor == 0xFF0000 },
+		},
+		{
+			name:  "color without hash",
+			key:   ConfigKeyColor,
+			value: "00FF00",
+			want:  func(s models.GuildSettings) bool { return s.BoardColor == 0x00FF00 },
+		},
+		{name: "color too short", key: ConfigKeyColor, value: "#fff", wantErr: true},
+		{name: "color name", key: ConfigKeyColor, value: "red", wantErr: true},
+		{
+			name:  "sort by debt",
+			key:   ConfigKeySort,
+			value: "Debt",
+			want:  func(s models.GuildSettings) bool { return s.SortOrder == models.SortOrderDebt },
+		},
+		{name: "unknown sort order", key: ConfigKeySort, value: "age", wantErr: true},
+		{
+			name:  "amount",
+			key:   ConfigKeyAmount,
+			value: "25k",
+			want:  func(s models.GuildSettings) bool { return s.PenaltyAmount == 25000 },
+		},
+		{name: "invalid amount", key: ConfigKeyAmount, value: "lots", wantErr: true},
+		{
+			name:  "language",
+			key:   ConfigKeyLanguage,
+			value: "DE",
+			want:  func(s models.GuildSettings) bool { return s.Locale == "de" },
+		},
+		{
+			name:  "language of the client",
+			key:   ConfigKeyLanguage,
+			value: ConfigValueLanguageAuto,
+			want:  func(s models.GuildSettings) bool { return s.Locale == "" },
+		},
+		{name: "unsupported language", key: ConfigKeyLanguage, value: "fr", wantErr: true},
+		{
+			name:  "reminder day",
+			key:   ConfigKeyReminderDay,
+			value: "Friday",
+			want:  func(s models.GuildSettings) bool { return s.ReminderWeekday == time.Friday },
+		},
+		{
+			name:  "reminders off",
+			key:   ConfigKeyReminderDay,
+			value: ConfigValueScheduleOff,
+			want:  func(s models.GuildSettings) bool { return s.ReminderWeekday == models.ScheduleOff },
+		},
+		{name: "unknown weekday", key: ConfigKeyReminderDay, value: "freitag", wantErr: true},
+		{
+			name:  "reminder time",
+			key:   ConfigKeyReminderTime,
+			value: "7:45",
+			want:  func(s models.GuildSettings) bool { return s.ReminderTime == 7*60+45 },
+		},
+		{name: "reminder time out of range", key: ConfigKeyReminderTime, value: "24:00", wantErr: true},
+		{
+			name:  "reminder threshold",
+			key:   ConfigKeyReminderThreshold,
+			value: "50k",
+			want:  func(s models.GuildSettings) bool { return s.ReminderThreshold == 50000 },
+		},
+		{name: "negative reminder threshold", key: ConfigKeyReminderThreshold, value: "-1", wantErr: true},
+		{
+			name:  "summary day",
+			key:   ConfigKeySummaryDay,
+			value: "sunday",
+			want:  func(s models.GuildSettings) bool { return s.SummaryWeekday == time.Sunday },
+		},
+		{
+			name:  "summary time",
+			key:   ConfigKeySummaryTime,
+			value: "20:00",
+			want:  func(s models.GuildSettings) bool { return s.SummaryTime == 20*60 },
+		},
+		{
+			name:  "timezone",
+			key:   ConfigKeyTimezone,
+			value: "Europe/Berlin",
+			want:  func(s models.GuildSettings) bool { return s.Timezone == "Europe/Berlin" },
+		},
+		{name: "local timezone", key: ConfigKeyTimezone, value: "Local", wantErr: true},
+		{name: "unknown timezone", key: ConfigKeyTimezone, value: "Mars/Olympus", wantErr: true},
+		{name: "unknown key", key: "prefix", value: "!", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				settings := models.DefaultGuildSettings("guild")
+				err := applySetting(&settings, tt.key, tt.value)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("applySetting() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if err != nil && !errors.Is(err, errInvalidConfigValue) {
+					t.Fatalf("applySetting() error = %v, want errInvalidConfigValue", err)
+				}
+				if tt.want != nil && !tt.want(settings) {
+					t.Errorf("applySetting() = %+v", settings)
+				}
+			},
+		)
+	}
+}
+
+func Test_resetSetting(t *testing.T) {
+	settings := models.DefaultGuildSettings("guild")
+	settings.BoardTitle = "title"
+	settings.BoardColor = 0xFF0000
+	settings.AdminRoleId = "role"
+
+	err := resetSetting(&settings, ConfigKeyColor)
+	if err != nil || settings.BoardColor != models.DefaultBoardColor || settings.BoardTitle != "title" {
+		t.Fatalf("Expected only the color to be reset, got %+v, %v", settings, err)
+	}
+	err = resetSetting(&settings, "")
+	if err != nil || settings.BoardTitle != models.DefaultBoardTitle || settings.AdminRoleId != "role" {
+		t.Fatalf("Expected all config keys but not the admin role to be reset, got %+v, %v", settings, err)
+	}
+}
+
+func Test_emojiMention(t *testing.T) {
+	if got := emojiMention("💰"); got != "💰" {
+		t.Errorf("emojiMention() = %v, want 💰", got)
+	}
+	if got := emojiMention("gold:1234567890"); got != "<:gold:1234567890>" {
+		t.Errorf("emojiMention() = %v, want <:gold:1234567890>", got)
+	}
+}
@@ -0,0 +1,273 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/diamondburned/arikawa/v3/utils/sendpart"
+	"github.com/rs/zerolog/log"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/i18n"
+	"slash10k/pkg/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ExportFormatCsv  = "csv"
+	ExportFormatJson = "json"
+
+	ExportScopeBalances = "balances"
+	ExportScopeJournal  = "journal"
+
+	// ExportDateLayout is the layout of the from and to dates, they are days in the timezone of the guild.
+	ExportDateLayout = time.DateOnly
+)
+
+// The columns of the exports are relied upon by spreadsheets of the guilds, so existing columns must
+// neither be renamed nor reordered. The json exports use the same names as keys, times are RFC 3339
+// in the timezone of the guild and amounts are whole gold. Names, categories and descriptions that a
+// spreadsheet would take for a formula, as they start with =, +, -, @, a tab or a carriage return,
+// are prefixed with ' in the csv exports.
+var (
+	// BalanceColumns are the discord id and name of a player, the debt, the sum of the payments that
+	// wait for approval, the time the debt last changed and whether the player is on the board. Players
+	// that left the board keep their debt, so they are exported as well.
+	BalanceColumns = []string{"discord_id", "name", "debt", "pending_payment", "last_updated", "active"}
+	// JournalColumns are the id and time of an entry, the discord id and name of the player, the amount
+	// that was added to the debt, negative for payments, the penalty category and the description.
+	JournalColumns = []string{"id", "date", "discord_id", "name", "amount", "category", "description"}
+)
+
+var errInvalidExportRange = errors.New("invalid export range")
+
+// csvFormulaPrefixes start a formula in spreadsheets.
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// csvText keeps a spreadsheet from evaluating text of users, e.g. a nickname like =HYPERLINK(...).
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune(csvFormulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+type exportRecord interface {
+	csvRecord() []string
+}
+
+type balanceRecord struct {
+	DiscordId      string `json:"discord_id"`
+	Name           string `json:"name"`
+	Debt           int64  `json:"debt"`
+	PendingPayment int64  `json:"pending_payment"`
+	LastUpdated    string `json:"last_updated"`
+	Active         bool   `json:"active"`
+}
+
+func (r balanceRecord) csvRecord() []string {
+	return []string{
+		r.DiscordId,
+		csvText(r.Name),
+		strconv.FormatInt(r.Debt, 10),
+		strconv.FormatInt(r.PendingPayment, 10),
+		r.LastUpdated,
+		strconv.FormatBool(r.Active),
+	}
+}
+
+type journalRecord struct {
+	Id          int32  `json:"id"`
+	Date        string `json:"date"`
+	DiscordId   string `json:"discord_id"`
+	Name        string `json:"name"`
+	Amount      int64  `json:"amount"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+}
+
+func (r journalRecord) csvRecord() []string {
+	return []string{
+		strconv.FormatInt(int64(r.Id), 10),
+		r.Date,
+		r.DiscordId,
+		csvText(r.Name),
+		strconv.FormatInt(r.Amount, 10),
+		csvText(r.Category),
+		csvText(r.Description),
+	}
+}
+
+func Export(service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.CommandData,
+) *api.InteractionResponseData {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		guildId := data.Event.GuildID
+		log.Info().Msgf("export called for guild %s", guildId)
+		locale := interactionLocale(ctx, service, data.Event)
+
+		format := data.Options.Find("format").String()
+		scope := data.Options.Find("scope").String()
+
+		settings, err := service.GetGuildSettings(ctx, guildId.String())
+		if err != nil {
+			log.Error().Msgf("cannot get guild settings: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.ExportFailed))
+		}
+		location, err := time.LoadLocation(settings.Timezone)
+		if err != nil {
+			log.Error().Msgf("cannot load timezone of guild %s: %s", guildId, err)
+			return ephemeralMessage(i18n.T(locale, i18n.ExportFailed))
+		}
+
+		now := time.Now()
+		from, to, err := parseExportRange(
+			data.Options.Find("from").String(),
+			data.Options.Find("to").String(),
+			now,
+			location,
+		)
+		if err != nil {
+			return ephemeralMessage(i18n.T(locale, i18n.ExportInvalidRange))
+		}
+
+		content, rows, err := export(ctx, service, guildId.String(), scope, format, from, to, location)
+		if err != nil {
+			log.Error().Msgf("cannot export %s: %s", scope, err)
+			return ephemeralMessage(i18n.T(locale, i18n.ExportFailed))
+		}
+
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString(i18n.T(locale, i18n.ExportReady, rows)),
+			Files: []sendpart.File{
+				{
+					Name:   fmt.Sprintf("10k-%s-%s.%s", scope, now.In(location).Format(ExportDateLayout), format),
+					Reader: bytes.NewReader(content),
+				},
+			},
+			Flags: discord.EphemeralMessage,
+		}
+	}
+}
+
+// export encodes the scope in the format and returns it with the number of exported rows.
+func export(
+	ctx context.Context,
+	service domain.Service,
+	guildId string,
+	scope string,
+	format string,
+	from time.Time,
+	to time.Time,
+	location *time.Location,
+) ([]byte, int, error) {
+	switch scope {
+	case ExportScopeBalances:
+		players, err := service.GetAllPlayersWithInactive(ctx, guildId)
+		if err != nil {
+			return nil, 0, err
+		}
+		records := balanceRecords(players, location)
+		content, err := encodeExport(format, BalanceColumns, records)
+		return content, len(records), err
+	case ExportScopeJournal:
+		entries, err := service.GetJournal(ctx, guildId, from, to)
+		if err != nil {
+			return nil, 0, err
+		}
+		records := journalRecords(entries, location)
+		content, err := encodeExport(format, JournalColumns, records)
+		return content, len(records), err
+	default:
+		return nil, 0, fmt.Errorf("unknown scope '%s'", scope)
+	}
+}
+
+// parseExportRange returns the start of the from day and the end of the to day. Without from the
+// export starts with the first entry, without to it ends now.
+func parseExportRange(from string, to string, now time.Time, location *time.Location) (time.Time, time.Time, error) {
+	start := time.Time{}
+	if from != "" {
+		day, err := time.ParseInLocation(ExportDateLayout, from, location)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: %s", errInvalidExportRange, err)
+		}
+		start = day
+	}
+	// the end is exclusive, entries of this very moment are included anyway
+	end := now.Add(time.Second)
+	if to != "" {
+		day, err := time.ParseInLocation(ExportDateLayout, to, location)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: %s", errInvalidExportRange, err)
+		}
+		end = day.AddDate(0, 0, 1)
+	}
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: %s is after %s", errInvalidExportRange, from, to)
+	}
+	return start, end, nil
+}
+
+func balanceRecords(players models.Players, location *time.Location) []balanceRecord {
+	players.SortByName()
+	records := make([]balanceRecord, len(players))
+	for i, player := range players {
+		records[i] = balanceRecord{
+			DiscordId:      player.DiscordId,
+			Name:           player.Name,
+			Debt:           player.Debt.Amount,
+			PendingPayment: player.PendingPayment,
+			LastUpdated:    formatExportTime(player.Debt.LastUpdated, location),
+			Active:         player.Active,
+		}
+	}
+	return records
+}
+
+func journalRecords(entries []models.DebtJournalEntry, location *time.Location) []journalRecord {
+	records := make([]journalRecord, len(entries))
+	for i, entry := range entries {
+		records[i] = journalRecord{
+			Id:          entry.Id,
+			Date:        formatExportTime(entry.Date, location),
+			DiscordId:   entry.DiscordId,
+			Name:        entry.PlayerName,
+			Amount:      entry.Amount,
+			Category:    entry.Category,
+			Description: entry.Description,
+		}
+	}
+	return records
+}
+
+func formatExportTime(unix int64, location *time.Location) string {
+	return time.Unix(unix, 0).In(location).Format(time.RFC3339)
+}
+
+func encodeExport[R exportRecord](format string, columns []string, records []R) ([]byte, error) {
+	switch format {
+	case ExportFormatJson:
+		return json.MarshalIndent(records, "", "  ")
+	case ExportFormatCsv:
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		_ = w.Write(columns)
+		for _, record := range records {
+			_ = w.Write(record.csvRecord())
+		}
+		w.Flush()
+		return buf.Bytes(), w.Error()
+	default:
+		return nil, fmt.Errorf("unknown format '%s'", format)
+	}
+}
@@ -0,0 +1,148 @@
+package command
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slash10k/pkg/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_parseExportRange(t *testing.T) {
+	location := time.FixedZone("CET", 60*60)
+	now := time.Date(2024, 3, 31, 12, 0, 0, 0, location)
+	tests := []struct {
+		name      string
+		from      string
+		to        string
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   bool
+	}{
+		{name: "everything", wantStart: time.Time{}, wantEnd: now.Add(time.Second)},
+		{
+			name:      "whole days",
+			from:      "2024-03-01",
+			to:        "2024-03-01",
+			wantStart: time.Date(2024, 3, 1, 0, 0, 0, 0, location),
+			wantEnd:   time.Date(2024, 3, 2, 0, 0, 0, 0, location),
+		},
+		{name: "from after to", from: "2024-03-02", to: "2024-03-01", wantErr: true},
+		{name: "from in the future", from: "2024-04-01", wantErr: true},
+		{name: "not a date", from: "01.03.2024", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				start, end, err := parseExportRange(tt.from, tt.to, now, location)
+				if tt.wantErr {
+					if !errors.Is(err, errInvalidExportRange) {
+						t.Fatalf("parseExportRange() error = %v, want errInvalidExportRange", err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("parseExportRange() error = %v", err)
+				}
+				if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+					t.Errorf("parseExportRange() = %v, %v, want %v, %v", start, end, tt.wantStart, tt.wantEnd)
+				}
+			},
+		)
+	}
+}
+
+func Test_encodeExport(t *testing.T) {
+	records := journalRecords(
+		[]models.DebtJournalEntry{
+			{
+				Id:          7,
+				Amount:      -5000,
+				Description: "paid, finally",
+				Date:        time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC).Unix(),
+				DiscordId:   "123",
+				PlayerName:  "torfstack",
+			},
+			{
+				Id:          8,
+				Amount:      10000,
+				Description: "-late",
+				Date:        time.Date(2024, 3, 2, 18, 0, 0, 0, time.UTC).Unix(),
+				DiscordId:   "456",
+				PlayerName:  "=HYPERLINK(\"https://example.com\")",
+				Category:    "@raid",
+			},
+		}, time.UTC,
+	)
+
+	content, err := encodeExport(ExportFormatCsv, JournalColumns, records)
+	if err != nil {
+		t.Fatalf("encodeExport() error = %v", err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(string(content))).ReadAll()
+	if err != nil {
+		t.Fatalf("Could not read csv: %s", err)
+	}
+	want := [][]string{
+		JournalColumns,
+		{"7", "2024-03-01T18:00:00Z", "123", "torfstack", "-5000", "", "paid, finally"},
+		{"8", "2024-03-02T18:00:00Z", "456", "'=HYPERLINK(\"https://example.com\")", "10000", "'@raid", "'-late"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("encodeExport() = %v, want %v", rows, want)
+	}
+
+	content, err = encodeExport(ExportFormatJson, JournalColumns, records)
+	if err != nil {
+		t.Fatalf("encodeExport() error = %v", err)
+	}
+	var objects []map[string]any
+	if err := json.Unmarshal(content, &objects); err != nil {
+		t.Fatalf("Could not read json: %s", err)
+	}
+	if len(objects) != 2 || len(objects[0]) != len(JournalColumns) {
+		t.Fatalf("Expected two objects with all columns, got %v", objects)
+	}
+	if objects[1]["name"] != "=HYPERLINK(\"https://example.com\")" {
+		t.Errorf("Expected the json export to keep the name as it is, got %v", objects[1]["name"])
+	}
+	for _, column := range JournalColumns {
+		if _, ok := objects[0][column]; !ok {
+			t.Errorf("Expected key %s in json export, got %v", column, objects[0])
+		}
+	}
+
+	content, _ = encodeExport(ExportFormatJson, BalanceColumns, balanceRecords(nil, time.UTC))
+	if string(content) != "[]" {
+		t.Errorf("Expected an empty list without players, got %s", content)
+	}
+
+	content, err = encodeExport(
+		ExportFormatCsv,
+		BalanceColumns,
+		balanceRecords(
+			models.Players{
+				{DiscordId: "123", Name: "torfstack", Active: true, Debt: models.Debt{Amount: 10000}},
+				{DiscordId: "456", Name: "neruh", Debt: models.Debt{Amount: 5000}},
+			}, time.UTC,
+		),
+	)
+	if err != nil {
+		t.Fatalf("encodeExport() error = %v", err)
+	}
+	rows, err = csv.NewReader(strings.NewReader(string(content))).ReadAll()
+	if err != nil {
+		t.Fatalf("Could not read csv: %s", err)
+	}
+	want = [][]string{
+		BalanceColumns,
+		{"456", "neruh", "5000", "0", "1970-01-01T00:00:00Z", "false"},
+		{"123", "torfstack", "10000", "0", "1970-01-01T00:00:00Z", "true"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("encodeExport() = %v, want %v", rows, want)
+	}
+}
@@ -9,25 +9,61 @@ import (
 	"github.com/diamondburned/arikawa/v3/gateway"
 	"github.com/diamondburned/arikawa/v3/state"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
-	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"os"
 	"slash10k/pkg/domain"
+	"slash10k/pkg/i18n"
 	"slash10k/pkg/utils"
+	"strconv"
 	"strings"
+	"time"
+)
+
+// pendingPenalty is a penalty waiting for confirmation. The token belongs to the interaction
+// that showed the prompt and is used to delete it, an amount of 0 uses the selected category.
+type pendingPenalty struct {
+	Token  string
+	Amount int64
+	Reason string
+}
+
+const (
+	// PendingConfirmationTtl matches the lifetime of interaction tokens,
+	// the prompt can not be deleted with an older token anyway.
+	PendingConfirmationTtl  = 15 * time.Minute
+	MaxPendingConfirmations = 1000
 )
 
 var (
-	tokenUuidMap = utils.SyncMap[string, string]{}
+	pendingConfirmations = utils.NewExpiringMap[string, pendingPenalty](PendingConfirmationTtl, MaxPendingConfirmations)
+
+	errPromptExpired = errors.New("prompt expired")
 )
 
-func RegisterDiscordHandlers(s *state.State, service domain.Service, lookup domain.MessageLookup) {
+func RegisterDiscordHandlers(
+	s *state.State,
+	service domain.Service,
+	lookup domain.MessageLookup,
+	authorizer *Authorizer,
+) {
+	registerHealingHandlers(s, service, lookup, authorizer)
+	registerLifecycleHandlers(s, service)
+	s.AddHandler(
+		func(*gateway.ReadyEvent) {
+			go reconcileRegistrations(context.Background(), s, service)
+		},
+	)
+	s.AddHandler(
+		func(*gateway.ResumedEvent) {
+			go reconcileRegistrations(context.Background(), s, service)
+		},
+	)
 	s.AddHandler(
 		func(event *gateway.MessageReactionAddEvent) {
 			ctx := context.Background()
 			isRegistrationMessage := lookup.IsRegistrationMessage(event.MessageID.String())
 			if isRegistrationMessage {
-				if event.Emoji.Name != "💰" {
+				if !isRegistrationEmoji(ctx, service, event.GuildID, event.Emoji) {
 					return
 				}
 				log.Info().Msgf("reaction %s added on registration message", event.Emoji.Name)
@@ -36,7 +72,7 @@ func RegisterDiscordHandlers(s *state.State, service domain.Service, lookup doma
 					event.UserID.String(),
 					event.Member.User.Username,
 					event.GuildID.String(),
-					event.Member.User.DisplayName,
+					memberName(event.Member.Nick, event.Member.User),
 				)
 				if err != nil && !errors.Is(err, domain.ErrPlayerAlreadyExists) {
 					log.Error().Msgf("could not add player: %s", err)
@@ -45,7 +81,26 @@ func RegisterDiscordHandlers(s *state.State, service domain.Service, lookup doma
 					log.Warn().Msgf("could not add player: %s", err)
 					return
 				}
-				updateDebtsMessage(ctx, s, service, event.GuildID.String())
+				scheduleDebtsMessageUpdate(s, service, event.GuildID.String())
+			}
+		},
+	)
+	s.AddHandler(
+		func(event *gateway.GuildMemberUpdateEvent) {
+			updated, err := service.UpdatePlayerName(
+				context.Background(),
+				event.User.ID.String(),
+				event.GuildID.String(),
+				event.User.Username,
+				memberName(event.Nick, event.User),
+			)
+			if err != nil {
+				log.Error().Msgf("could not update player name: %s", err)
+				return
+			}
+			if updated {
+				log.Info().Msgf("updated name of player %s in guild %s", event.User.ID, event.GuildID)
+				scheduleDebtsMessageUpdate(s, service, event.GuildID.String())
 			}
 		},
 	)
@@ -54,23 +109,23 @@ func RegisterDiscordHandlers(s *state.State, service domain.Service, lookup doma
 			ctx := context.Background()
 			isRegistrationMessage := lookup.IsRegistrationMessage(event.MessageID.String())
 			if isRegistrationMessage {
-				if event.Emoji.Name != "💰" {
+				if !isRegistrationEmoji(ctx, service, event.GuildID, event.Emoji) {
 					return
 				}
 				log.Info().Msgf("reaction %s removed on registration message", event.Emoji.Name)
-				err := service.DeletePlayer(
+				err := service.DeactivatePlayer(
 					ctx,
 					event.UserID.String(),
 					event.GuildID.String(),
 				)
 				if err != nil && !errors.Is(err, domain.ErrPlayerDoesNotExist) {
-					log.Error().Msgf("could not delete player: %s", err)
+					log.Error().Msgf("could not deactivate player: %s", err)
 					return
 				} else if errors.Is(err, domain.ErrPlayerDoesNotExist) {
-					log.Warn().Msgf("could not delete player: %s", err)
+					log.Warn().Msgf("could not deactivate player: %s", err)
 					return
 				}
-				updateDebtsMessage(ctx, s, service, event.GuildID.String())
+				scheduleDebtsMessageUpdate(s, service, event.GuildID.String())
 			}
 		},
 	)
@@ -80,49 +135,88 @@ func RegisterDiscordHandlers(s *state.State, service domain.Service, lookup doma
 			switch data := event.Data.(type) {
 			case *discord.ButtonInteraction:
 				switch {
-				case data.CustomID == ComponentIdPaid:
-					log.Info().Msgf("paid button interaction")
-					err := service.ResetDebt(ctx, event.SenderID().String(), event.GuildID.String())
-					if err != nil {
-						log.Error().Msgf("could not reset debt: %s", err)
-						return
-					}
-					updateDebtsMessage(ctx, s, service, event.GuildID.String())
+				case data.CustomID == ComponentIdPay:
+					log.Info().Msgf("pay button interaction")
+					respondWithPayModal(ctx, s, service, event)
+					return
+				case strings.HasPrefix(string(data.CustomID), ComponentIdPlayerPage):
+					log.Info().Msgf("player page button interaction")
+					respondWithPlayerPage(ctx, s, service, event, string(data.CustomID))
+					return
+				case strings.HasPrefix(string(data.CustomID), ComponentIdApprovePayment),
+					strings.HasPrefix(string(data.CustomID), ComponentIdRejectPayment):
+					log.Info().Msgf("payment decision button interaction")
+					handlePaymentDecision(ctx, s, service, authorizer, event, string(data.CustomID))
+					return
+				case strings.HasPrefix(string(data.CustomID), ComponentIdConfirmImport),
+					strings.HasPrefix(string(data.CustomID), ComponentIdCancelImport):
+					log.Info().Msgf("import decision button interaction")
+					handleImportDecision(ctx, s, service, authorizer, event, string(data.CustomID))
+					return
+				case strings.HasPrefix(string(data.CustomID), ComponentIdHistoryPage):
+					log.Info().Msgf("history page button interaction")
+					respondWithHistoryPage(ctx, s, service, event, string(data.CustomID))
+					return
 				case strings.HasPrefix(string(data.CustomID), ComponentIdCancelButton):
 					appIdSnowflake, err := discord.ParseSnowflake(os.Getenv("APPLICATION_ID"))
 					if err != nil {
 						log.Error().Msgf("could not parse application id: %s", err)
 						return
 					}
-					updateDebtsMessage(ctx, s, service, event.GuildID.String())
-					_, originalToken, err := extractPlayerAndToken(string(data.CustomID))
-					if err != nil {
+					_, pending, _, err := extractPlayerTokenAndCategory(string(data.CustomID))
+					if errors.Is(err, errPromptExpired) {
+						respondWithPromptExpired(s, event, interactionLocale(ctx, service, &event.InteractionEvent))
+						return
+					} else if err != nil {
 						log.Error().Msgf("could not extract player and token: %s", err)
 						return
 					}
-					err = s.DeleteInteractionResponse(discord.AppID(appIdSnowflake), originalToken)
+					scheduleDebtsMessageUpdate(s, service, event.GuildID.String())
+					err = s.DeleteInteractionResponse(discord.AppID(appIdSnowflake), pending.Token)
 					if err != nil {
 						log.Error().Msgf("could not delete interaction response: %s", err)
 						return
 					}
 				case strings.HasPrefix(string(data.CustomID), ComponentIdConfirmButton):
-					player, originalToken, err := extractPlayerAndToken(string(data.CustomID))
-					if err != nil {
+					player, pending, categoryId, err := extractPlayerTokenAndCategory(string(data.CustomID))
+					if errors.Is(err, errPromptExpired) {
+						respondWithPromptExpired(s, event, interactionLocale(ctx, service, &event.InteractionEvent))
+						return
+					} else if err != nil {
 						log.Error().Msgf("could not extract player and token: %s", err)
 						return
 					}
-					err = service.AddDebt(ctx, player, event.GuildID.String(), 10000)
+					category, amount := "", pending.Amount
+					if amount == 0 {
+						category, amount, err = penaltyForCategory(ctx, service, event.GuildID.String(), categoryId)
+						if errors.Is(err, domain.ErrPenaltyCategoryDoesNotExist) {
+							locale := interactionLocale(ctx, service, &event.InteractionEvent)
+							respondEphemeral(s, event, i18n.T(locale, i18n.CategoryDeleted))
+							return
+						} else if err != nil {
+							log.Error().Msgf("could not get penalty for category: %s", err)
+							return
+						}
+					}
+					err = service.AddDebt(
+						ctx,
+						player,
+						event.GuildID.String(),
+						amount,
+						category,
+						penaltyDescription(category, senderName(&event.InteractionEvent), pending.Reason),
+					)
 					if err != nil {
 						log.Error().Msgf("could not add debt: %s", err)
 						return
 					}
-					updateDebtsMessage(ctx, s, service, event.GuildID.String())
+					scheduleDebtsMessageUpdate(s, service, event.GuildID.String())
 					appIdSnowflake, err := discord.ParseSnowflake(os.Getenv("APPLICATION_ID"))
 					if err != nil {
 						log.Error().Msgf("could not parse application id: %s", err)
 						return
 					}
-					err = s.DeleteInteractionResponse(discord.AppID(appIdSnowflake), originalToken)
+					err = s.DeleteInteractionResponse(discord.AppID(appIdSnowflake), pending.Token)
 					if err != nil {
 						log.Error().Msgf("could not delete interaction response: %s", err)
 						return
@@ -138,8 +232,13 @@ func RegisterDiscordHandlers(s *state.State, service domain.Service, lookup doma
 					log.Error().Msgf("could not respond to interaction: %s", err)
 					return
 				}
+			case *discord.ModalInteraction:
+				if data.CustomID == ComponentIdPayModal {
+					log.Info().Msgf("pay modal interaction")
+					handlePayModal(ctx, s, service, event, data)
+				}
 			case *discord.StringSelectInteraction:
-				if data.CustomID == ComponentIdSelectPlayer {
+				if strings.HasPrefix(string(data.CustomID), ComponentIdSelectPlayer) {
 					log.Info().Msgf("select player interaction")
 					if len(data.Values) != 1 {
 						log.Error().Msgf("invalid number of players selected: %v", len(data.Values))
@@ -150,22 +249,30 @@ func RegisterDiscordHandlers(s *state.State, service domain.Service, lookup doma
 						log.Error().Msgf("could not get player: %s", err)
 						return
 					}
-					u := uuid.NewString()
-					tokenUuidMap.Store(u, event.Token)
+					responseData, err := penaltyPromptData(
+						ctx,
+						service,
+						*player,
+						pendingPenalty{Token: event.Token},
+						interactionLocale(ctx, service, &event.InteractionEvent),
+					)
+					if err != nil {
+						log.Error().Msgf("could not prepare penalty prompt: %s", err)
+						return
+					}
 					err = s.RespondInteraction(
 						event.ID, event.Token, api.InteractionResponse{
 							Type: api.MessageInteractionWithSource,
-							Data: &api.InteractionResponseData{
-								Content:    option.NewNullableString("Do you really want to add 10k to " + player.Name + "?"),
-								Components: confirmOrCancelButtonComponents(player.DiscordId, u),
-								Flags:      discord.EphemeralMessage,
-							},
+							Data: responseData,
 						},
 					)
 					if err != nil {
 						log.Error().Msgf("could not respond to interaction: %s", err)
 						return
 					}
+				} else if strings.HasPrefix(string(data.CustomID), ComponentIdSelectCategory) {
+					log.Info().Msgf("select category interaction")
+					respondWithPenaltyConfirmation(ctx, s, service, event, data)
 				}
 			default:
 				return
@@ -175,36 +282,80 @@ func RegisterDiscordHandlers(s *state.State, service domain.Service, lookup doma
 }
 
 const (
-	ComponentIdCancelButton     = "CANCEL"
-	ComponentLabelCancelButton  = "Cancel"
-	ComponentIdConfirmButton    = "CONFIRM"
-	ComponentLabelConfirmButton = "Confirm"
+	ComponentIdCancelButton  = "CANCEL"
+	ComponentIdConfirmButton = "CONFIRM"
 )
 
-func confirmOrCancelButtonComponents(player string, token string) *discord.ContainerComponents {
+func confirmOrCancelButtonComponents(
+	player string,
+	token string,
+	categoryId int32,
+	locale i18n.Locale,
+) *discord.ContainerComponents {
 	return &discord.ContainerComponents{
 		&discord.ActionRowComponent{
 			&discord.ButtonComponent{
 				Style:    discord.SecondaryButtonStyle(),
-				CustomID: discord.ComponentID(fmt.Sprintf("%s||%s||%s", ComponentIdCancelButton, player, token)),
-				Label:    ComponentLabelCancelButton,
+				CustomID: penaltyComponentId(ComponentIdCancelButton, player, token, categoryId),
+				Label:    i18n.T(locale, i18n.Cancel),
 			},
 			&discord.ButtonComponent{
 				Style:    discord.DangerButtonStyle(),
-				CustomID: discord.ComponentID(fmt.Sprintf("%s||%s||%s", ComponentIdConfirmButton, player, token)),
-				Label:    ComponentLabelConfirmButton,
+				CustomID: penaltyComponentId(ComponentIdConfirmButton, player, token, categoryId),
+				Label:    i18n.T(locale, i18n.Confirm),
 			},
 		},
 	}
 }
 
-func extractPlayerAndToken(customId string) (string, string, error) {
+func penaltyComponentId(prefix string, player string, token string, categoryId int32) discord.ComponentID {
+	return discord.ComponentID(fmt.Sprintf("%s||%s||%s||%d", prefix, player, token, categoryId))
+}
+
+// respondWithPromptExpired replaces a prompt whose token expired or was lost on a restart.
+func respondWithPromptExpired(s *state.State, event *gateway.InteractionCreateEvent, locale i18n.Locale) {
+	err := s.RespondInteraction(
+		event.ID, event.Token, api.InteractionResponse{
+			Type: api.UpdateMessage,
+			Data: &api.InteractionResponseData{
+				Content:    option.NewNullableString(i18n.T(locale, i18n.PromptExpired)),
+				Components: &discord.ContainerComponents{},
+			},
+		},
+	)
+	if err != nil {
+		log.Error().Msgf("could not respond to interaction: %s", err)
+	}
+}
+
+func extractPlayerTokenAndCategory(customId string) (string, pendingPenalty, int32, error) {
 	playerAndToken := strings.TrimPrefix(customId, ComponentIdCancelButton+"||")
 	playerAndToken = strings.TrimPrefix(playerAndToken, ComponentIdConfirmButton+"||")
 	components := strings.Split(playerAndToken, "||")
-	token, ok := tokenUuidMap.LoadAndRemove(components[1])
+	if len(components) < 2 {
+		return "", pendingPenalty{}, 0, errors.New("malformed component id")
+	}
+	categoryId := DefaultCategoryId
+	if len(components) > 2 {
+		id, err := strconv.Atoi(components[2])
+		if err != nil {
+			return "", pendingPenalty{}, 0, fmt.Errorf("could not parse category id: %w", err)
+		}
+		categoryId = int32(id)
+	}
+	pending, ok := pendingConfirmations.LoadAndRemove(components[1])
 	if !ok {
-		return "", "", errors.New("could not load token")
+		return "", pendingPenalty{}, 0, errPromptExpired
+	}
+	return components[0], pending, categoryId, nil
+}
+
+// isRegistrationEmoji reports whether the emoji is the one the guild uses to register players.
+func isRegistrationEmoji(ctx context.Context, service domain.Service, guildId discord.GuildID, emoji discord.Emoji) bool {
+	settings, err := service.GetGuildSettings(ctx, guildId.String())
+	if err != nil {
+		log.Error().Msgf("could not get guild settings: %s", err)
+		return false
 	}
-	return components[0], token, nil
+	return string(emoji.APIString()) == settings.RegistrationEmoji
 }
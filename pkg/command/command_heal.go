@@ -0,0 +1,149 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/rs/zerolog/log"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/i18n"
+	"slash10k/pkg/models"
+)
+
+const (
+	// ErrorCodeUnknownMessage is returned by Discord for messages that do not exist (anymore).
+	ErrorCodeUnknownMessage httputil.ErrorCode = 10008
+)
+
+func isUnknownMessage(err error) bool {
+	var httpErr *httputil.HTTPError
+	return errors.As(err, &httpErr) && httpErr.Code == ErrorCodeUnknownMessage
+}
+
+func registerHealingHandlers(
+	s *state.State,
+	service domain.Service,
+	lookup domain.MessageLookup,
+	authorizer *Authorizer,
+) {
+	s.AddHandler(
+		func(event *gateway.MessageDeleteEvent) {
+			if !lookup.IsBoardChannel(event.ChannelID.String()) {
+				return
+			}
+			ctx := context.Background()
+			botSetup, err := service.GetBotSetup(ctx, event.GuildID.String())
+			if err != nil {
+				log.Error().Msgf("could not get bot setup: %s", err)
+				return
+			}
+			switch event.ID.String() {
+			case botSetup.RegistrationMessageId:
+				log.Warn().Msgf("registration message of guild %s was deleted, restoring it", event.GuildID)
+				err = restoreRegistrationMessage(ctx, s, service, lookup, *botSetup)
+			case botSetup.DebtsMessageId:
+				// the render restores the missing message, in turn with the edits of the guild
+				log.Warn().Msgf("debts message of guild %s was deleted, restoring it", event.GuildID)
+				scheduleDebtsMessageUpdate(s, service, event.GuildID.String())
+			}
+			if err != nil {
+				log.Error().Msgf("could not restore message: %s", err)
+			}
+		},
+	)
+	s.AddHandler(
+		func(event *gateway.ChannelDeleteEvent) {
+			if !lookup.IsBoardChannel(event.ID.String()) {
+				return
+			}
+			ctx := context.Background()
+			botSetup, err := service.GetBotSetup(ctx, event.GuildID.String())
+			if err != nil {
+				log.Error().Msgf("could not get bot setup: %s", err)
+				return
+			}
+			log.Warn().Msgf("channel of the board of guild %s was deleted, removing the setup", event.GuildID)
+			err = service.DeleteBotSetup(ctx, event.GuildID.String())
+			if err != nil {
+				log.Error().Msgf("could not delete bot setup: %s", err)
+				return
+			}
+			lookup.RemoveSetup(*botSetup)
+
+			guildName := event.GuildID.String()
+			if guild, err := s.Guild(event.GuildID); err == nil {
+				guildName = guild.Name
+			}
+			locale := i18n.DefaultLocale
+			if settings, err := service.GetGuildSettings(ctx, event.GuildID.String()); err == nil {
+				locale = guildLocale(s, *settings)
+			}
+			sendDirectMessages(s, authorizer.Admins(event.GuildID), i18n.T(locale, i18n.ChannelDeletedMessage, guildName))
+		},
+	)
+}
+
+// restoreDebtsMessage sends the debts message again and points the setup to it.
+func restoreDebtsMessage(
+	ctx context.Context,
+	s *state.State,
+	service domain.Service,
+	botSetup models.BotSetup,
+) error {
+	channelId, _ := botSetupToDiscordTypes(botSetup)
+	m, err := sendDebtsMessage(ctx, s, service, botSetup.GuildId, channelId)
+	if err != nil {
+		return fmt.Errorf("could not send debts message: %w", err)
+	}
+	return service.UpdateBotSetupMessages(ctx, botSetup.GuildId, botSetup.RegistrationMessageId, m.ID.String())
+}
+
+// restoreRegistrationMessage sends the registration message again and points the setup to it.
+// The reactions are gone with the old message, so players are not deactivated until they reacted again.
+func restoreRegistrationMessage(
+	ctx context.Context,
+	s *state.State,
+	service domain.Service,
+	lookup domain.MessageLookup,
+	botSetup models.BotSetup,
+) error {
+	settings, err := service.GetGuildSettings(ctx, botSetup.GuildId)
+	if err != nil {
+		return fmt.Errorf("could not get guild settings: %w", err)
+	}
+	err = service.SetAwaitingReactions(ctx, botSetup.GuildId, true)
+	if err != nil {
+		return err
+	}
+	channelId, _ := botSetupToDiscordTypes(botSetup)
+	m, err := sendRegistrationMessageWithContent(s, channelId, i18n.RestoredRegistrationMessage, *settings)
+	if err != nil {
+		return fmt.Errorf("could not send registration message: %w", err)
+	}
+	err = service.UpdateBotSetupMessages(ctx, botSetup.GuildId, m.ID.String(), botSetup.DebtsMessageId)
+	if err != nil {
+		return err
+	}
+	lookup.RemoveSetup(botSetup)
+	botSetup.RegistrationMessageId = m.ID.String()
+	lookup.AddSetup(botSetup)
+	return nil
+}
+
+func sendDirectMessages(s *state.State, users []discord.UserID, content string) {
+	for _, user := range users {
+		channel, err := s.CreatePrivateChannel(user)
+		if err != nil {
+			log.Error().Msgf("could not create private channel with %s: %s", user, err)
+			continue
+		}
+		_, err = s.SendMessage(channel.ID, content)
+		if err != nil {
+			log.Error().Msgf("could not send direct message to %s: %s", user, err)
+		}
+	}
+}
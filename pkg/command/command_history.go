@@ -0,0 +1,232 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/i18n"
+	"slash10k/pkg/models"
+	"slash10k/pkg/utils"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ComponentIdHistoryPage = "HISTORY_PAGE"
+
+	HistoryPageSize = 10
+	// HistoryCategoryTtl is how long the page buttons of a history filtered by category keep working.
+	HistoryCategoryTtl   = time.Hour
+	MaxHistoryCategories = 1000
+
+	JournalDescriptionCorrection = "corrected by %s"
+)
+
+// historyCategories holds the category filters of the shown histories. A category may be too long for
+// the custom id of the page buttons, which only carry its key.
+var historyCategories = utils.NewExpiringMap[string, string](HistoryCategoryTtl, MaxHistoryCategories)
+
+// historyFilter selects the journal entries of a history, categoryKey is the key of the category in
+// historyCategories.
+type historyFilter struct {
+	discordId   string
+	category    string
+	categoryKey string
+}
+
+func newHistoryFilter(discordId string, category string) historyFilter {
+	filter := historyFilter{discordId: discordId, category: category}
+	if category != "" {
+		filter.categoryKey = uuid.NewString()
+		historyCategories.Store(filter.categoryKey, category)
+	}
+	return filter
+}
+
+func History(service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.CommandData,
+) *api.InteractionResponseData {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		guildId := data.Event.GuildID
+		log.Info().Msgf("history called for guild %s", guildId)
+		locale := interactionLocale(ctx, service, data.Event)
+
+		discordId := ""
+		if player := data.Options.Find("player"); player.Name != "" {
+			id, err := player.SnowflakeValue()
+			if err != nil {
+				log.Error().Msgf("cannot get player: %s", err)
+				return ephemeralMessage(i18n.T(locale, i18n.HistoryFailed))
+			}
+			discordId = discord.UserID(id).String()
+		}
+
+		filter := newHistoryFilter(discordId, data.Options.Find("category").String())
+
+		res, err := historyPage(ctx, service, guildId.String(), filter, 0, locale)
+		if err != nil {
+			log.Error().Msgf("cannot get history page: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.HistoryFailed))
+		}
+		res.Flags = discord.EphemeralMessage
+		return res
+	}
+}
+
+func respondWithHistoryPage(
+	ctx context.Context,
+	s *state.State,
+	service domain.Service,
+	event *gateway.InteractionCreateEvent,
+	customId string,
+) {
+	locale := interactionLocale(ctx, service, &event.InteractionEvent)
+	filter, page, err := extractHistoryPage(customId)
+	if errors.Is(err, errPromptExpired) {
+		respondWithPromptExpired(s, event, locale)
+		return
+	} else if err != nil {
+		log.Error().Msgf("could not extract history page: %s", err)
+		return
+	}
+	data, err := historyPage(ctx, service, event.GuildID.String(), filter, page, locale)
+	if err != nil {
+		log.Error().Msgf("could not get history page: %s", err)
+		return
+	}
+	err = s.RespondInteraction(
+		event.ID, event.Token, api.InteractionResponse{
+			Type: api.UpdateMessage,
+			Data: data,
+		},
+	)
+	if err != nil {
+		log.Error().Msgf("could not respond to interaction: %s", err)
+		return
+	}
+}
+
+func historyPage(
+	ctx context.Context,
+	service domain.Service,
+	guildId string,
+	filter historyFilter,
+	page int,
+	locale i18n.Locale,
+) (*api.InteractionResponseData, error) {
+	settings, err := service.GetGuildSettings(ctx, guildId)
+	if err != nil {
+		return nil, err
+	}
+	// Fetch one entry more than shown to know whether there is a next page.
+	entries, err := service.GetHistory(
+		ctx,
+		guildId,
+		filter.discordId,
+		filter.category,
+		HistoryPageSize+1,
+		int32(page*HistoryPageSize),
+	)
+	if err != nil {
+		return nil, err
+	}
+	hasNext := len(entries) > HistoryPageSize
+	if hasNext {
+		entries = entries[:HistoryPageSize]
+	}
+	return &api.InteractionResponseData{
+		Embeds:     &[]discord.Embed{transformHistoryToEmbed(entries, filter.category, *settings, page, locale)},
+		Components: historyPageButtonComponents(filter, page, hasNext, locale),
+	}, nil
+}
+
+func transformHistoryToEmbed(
+	entries []models.DebtJournalEntry,
+	category string,
+	settings models.GuildSettings,
+	page int,
+	locale i18n.Locale,
+) discord.Embed {
+	description := strings.Builder{}
+	if len(entries) == 0 {
+		description.WriteString(i18n.T(locale, i18n.HistoryEmpty))
+	}
+	for _, e := range entries {
+		description.WriteString(
+			fmt.Sprintf("<t:%d:d> `%+d` **%s** %s\n", e.Date, e.Amount, e.PlayerName, e.Description),
+		)
+	}
+	title := i18n.T(locale, i18n.HistoryTitle)
+	if category != "" {
+		title += ": " + category
+	}
+	return discord.Embed{
+		Title:       title,
+		Type:        discord.NormalEmbed,
+		Description: description.String(),
+		Footer:      &discord.EmbedFooter{Text: i18n.T(locale, i18n.HistoryPage, page+1)},
+		Color:       discord.Color(settings.BoardColor),
+	}
+}
+
+func historyPageButtonComponents(
+	filter historyFilter,
+	page int,
+	hasNext bool,
+	locale i18n.Locale,
+) *discord.ContainerComponents {
+	return &discord.ContainerComponents{
+		&discord.ActionRowComponent{
+			&discord.ButtonComponent{
+				Style:    discord.SecondaryButtonStyle(),
+				CustomID: historyPageComponentId(filter, page-1),
+				Label:    i18n.T(locale, i18n.Previous),
+				Disabled: page == 0,
+			},
+			&discord.ButtonComponent{
+				Style:    discord.SecondaryButtonStyle(),
+				CustomID: historyPageComponentId(filter, page+1),
+				Label:    i18n.T(locale, i18n.Next),
+				Disabled: !hasNext,
+			},
+		},
+	}
+}
+
+func historyPageComponentId(filter historyFilter, page int) discord.ComponentID {
+	return discord.ComponentID(
+		fmt.Sprintf("%s||%d||%s||%s", ComponentIdHistoryPage, page, filter.discordId, filter.categoryKey),
+	)
+}
+
+// extractHistoryPage returns errPromptExpired if the category of the history is no longer known.
+func extractHistoryPage(customId string) (historyFilter, int, error) {
+	components := strings.Split(strings.TrimPrefix(customId, ComponentIdHistoryPage+"||"), "||")
+	if len(components) < 2 || len(components) > 3 {
+		return historyFilter{}, 0, errors.New("malformed history page component id")
+	}
+	page, err := strconv.Atoi(components[0])
+	if err != nil || page < 0 {
+		return historyFilter{}, 0, fmt.Errorf("invalid page %q", components[0])
+	}
+	filter := historyFilter{discordId: components[1]}
+	if len(components) == 3 && components[2] != "" {
+		filter.categoryKey = components[2]
+		category, ok := historyCategories.Load(filter.categoryKey)
+		if !ok {
+			return historyFilter{}, 0, errPromptExpired
+		}
+		filter.category = category
+	}
+	return filter, page, nil
+}
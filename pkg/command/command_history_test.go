@@ -0,0 +1,34 @@
+package command
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func Test_historyPageComponentId(t *testing.T) {
+	category := strings.Repeat("late||", 20)
+	filter := newHistoryFilter("123456789012345678", category)
+
+	customId := string(historyPageComponentId(filter, 2))
+	if len(customId) > 100 {
+		t.Errorf("Expected at most 100 characters, got %d in %s", len(customId), customId)
+	}
+	got, page, err := extractHistoryPage(customId)
+	if err != nil {
+		t.Fatalf("extractHistoryPage() error = %v", err)
+	}
+	if got != filter || page != 2 {
+		t.Errorf("extractHistoryPage() = %v, %d, want %v, 2", got, page, filter)
+	}
+
+	historyCategories.LoadAndRemove(filter.categoryKey)
+	if _, _, err := extractHistoryPage(customId); !errors.Is(err, errPromptExpired) {
+		t.Errorf("Expected errPromptExpired for a forgotten category, got %v", err)
+	}
+
+	got, _, err = extractHistoryPage(string(historyPageComponentId(newHistoryFilter("", ""), 0)))
+	if err != nil || got != (historyFilter{}) {
+		t.Errorf("extractHistoryPage() = %v, %v, want an empty filter", got, err)
+	}
+}
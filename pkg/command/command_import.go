@@ -0,0 +1,387 @@
+package command
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"io"
+	"net/http"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/i18n"
+	"slash10k/pkg/models"
+	"slash10k/pkg/utils"
+	"strings"
+	"time"
+)
+
+const (
+	ComponentIdConfirmImport = "CONFIRM_IMPORT"
+	ComponentIdCancelImport  = "CANCEL_IMPORT"
+
+	JournalDescriptionImport = "imported by %s"
+
+	// ImportDownloadTimeout bounds the download of the file, the answer to the interaction is deferred meanwhile.
+	ImportDownloadTimeout = 10 * time.Second
+	MaxImportSize         = 1 << 20
+	MaxImportRows         = 1000
+	MaxPendingImports     = 100
+	// MaxImportPreviewLength keeps the preview below the 4096 characters Discord allows in a description.
+	MaxImportPreviewLength = 4000
+)
+
+var (
+	pendingImports = utils.NewExpiringMap[string, []models.ImportChange](PendingConfirmationTtl, MaxPendingImports)
+
+	// importPlayerColumns are the headers of the column of the player, the first one that is filled is used.
+	importPlayerColumns  = []string{"discord_id", "name", "player"}
+	importBalanceColumns = []string{"balance", "debt"}
+	importNoteColumns    = []string{"note"}
+)
+
+// importFileError is caused by a file that can not be imported and describes the problem to the user.
+type importFileError struct {
+	key  i18n.Key
+	args []any
+}
+
+func (e importFileError) Error() string {
+	return e.Message(i18n.DefaultLocale)
+}
+
+func (e importFileError) Message(locale i18n.Locale) string {
+	return i18n.T(locale, e.key, e.args...)
+}
+
+// Import shows which debts an uploaded csv file would change, the changes are applied once they are confirmed.
+// Downloading and planning the import may take longer than Discord waits for an answer, so the route is
+// deferrable and the preview follows up.
+func Import(s *state.State, service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.CommandData,
+) *api.InteractionResponseData {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		guildId := data.Event.GuildID
+		log.Info().Msgf("import called for guild %s", guildId)
+		locale := interactionLocale(ctx, service, data.Event)
+
+		attachmentId, err := data.Options.Find("file").SnowflakeValue()
+		if err != nil {
+			log.Error().Msgf("cannot get file: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.ImportFailed))
+		}
+		attachment, ok := data.Data.Resolved.Attachments[discord.AttachmentID(attachmentId)]
+		if !ok {
+			log.Error().Msgf("cannot find attachment %s", attachmentId)
+			return ephemeralMessage(i18n.T(locale, i18n.ImportFailed))
+		}
+
+		rows, err := downloadImport(ctx, attachment)
+		var fileErr importFileError
+		if errors.As(err, &fileErr) {
+			return ephemeralMessage(fileErr.Message(locale))
+		} else if err != nil {
+			log.Error().Msgf("cannot download import: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.ImportFailed))
+		}
+
+		changes, err := service.PlanImport(ctx, guildId.String(), rows)
+		if err == nil {
+			err = addNewImportPlayers(s, guildId, changes)
+		}
+		if errors.Is(err, domain.ErrDuplicateImportRow) {
+			log.Warn().Msgf("cannot plan import: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.ImportDuplicate))
+		} else if err != nil {
+			log.Error().Msgf("cannot plan import: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.ImportFailed))
+		}
+
+		return importPreview(changes, locale)
+	}
+}
+
+func downloadImport(ctx context.Context, attachment discord.Attachment) ([]models.ImportRow, error) {
+	if attachment.Size > MaxImportSize {
+		return nil, importFileError{key: i18n.ImportTooLarge, args: []any{MaxImportSize >> 10}}
+	}
+	ctx, cancel := context.WithTimeout(ctx, ImportDownloadTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, attachment.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", res.Status)
+	}
+	return parseImport(io.LimitReader(res.Body, MaxImportSize))
+}
+
+// parseImport reads rows of a discord id or name, a balance and an optional note. The columns are
+// found by their header if the file has one, which is the case if the balance of the first row is
+// not an amount. The exported balances can be imported that way. Balances must not be negative.
+func parseImport(r io.Reader) ([]models.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, importFileError{key: i18n.ImportInvalidFile}
+	}
+	if len(records) == 0 {
+		return nil, importFileError{key: i18n.ImportEmpty}
+	}
+
+	playerColumns, balanceColumn, noteColumn := []int{0}, 1, 2
+	first := 0
+	if _, err := parseAmount(importField(records[0], balanceColumn)); err != nil {
+		playerColumns, balanceColumn, noteColumn = importColumns(records[0])
+		if len(playerColumns) == 0 || balanceColumn < 0 {
+			return nil, importFileError{key: i18n.ImportInvalidHeader}
+		}
+		first = 1
+	}
+	if len(records)-first > MaxImportRows {
+		return nil, importFileError{key: i18n.ImportTooManyRows, args: []any{MaxImportRows}}
+	}
+
+	rows := make([]models.ImportRow, 0, len(records)-first)
+	for i, record := range records[first:] {
+		line := first + i + 1
+		row := models.ImportRow{Line: line, Note: truncate(importField(record, noteColumn), MaxPenaltyReasonLength)}
+		for _, column := range playerColumns {
+			if row.Player = importField(record, column); row.Player != "" {
+				break
+			}
+		}
+		amount, err := parseAmount(importField(record, balanceColumn))
+		if row.Player == "" || err != nil || amount < 0 {
+			return nil, importFileError{key: i18n.ImportInvalidRow, args: []any{line}}
+		}
+		row.Amount = amount
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, importFileError{key: i18n.ImportEmpty}
+	}
+	return rows, nil
+}
+
+// importColumns finds the columns in the header, the column of the note is -1 if there is none.
+func importColumns(header []string) ([]int, int, int) {
+	find := func(names []string) []int {
+		columns := make([]int, 0, len(names))
+		for _, name := range names {
+			for i, column := range header {
+				if strings.EqualFold(strings.TrimSpace(column), name) {
+					columns = append(columns, i)
+					break
+				}
+			}
+		}
+		return columns
+	}
+	balanceColumn, noteColumn := -1, -1
+	if columns := find(importBalanceColumns); len(columns) > 0 {
+		balanceColumn = columns[0]
+	}
+	if columns := find(importNoteColumns); len(columns) > 0 {
+		noteColumn = columns[0]
+	}
+	return find(importPlayerColumns), balanceColumn, noteColumn
+}
+
+// importField returns the trimmed field of the record, or an empty string if the record is too short.
+// The ' that csv exports put in front of text that looks like a formula is removed.
+func importField(record []string, column int) string {
+	if column < 0 || column >= len(record) {
+		return ""
+	}
+	field := strings.TrimSpace(record[column])
+	if len(field) > 1 && field[0] == '\'' && strings.ContainsRune(csvFormulaPrefixes, rune(field[1])) {
+		return field[1:]
+	}
+	return field
+}
+
+// addNewImportPlayers turns skipped rows with the discord id of a member of the guild into new players.
+func addNewImportPlayers(s *state.State, guildId discord.GuildID, changes []models.ImportChange) error {
+	lines := make(map[string]int, len(changes))
+	for _, change := range changes {
+		if !change.Skipped() {
+			lines[change.Player.DiscordId] = change.Row.Line
+		}
+	}
+	for i, change := range changes {
+		if !change.Skipped() {
+			continue
+		}
+		id, err := discord.ParseSnowflake(change.Row.Player)
+		if err != nil || !id.IsValid() {
+			continue
+		}
+		member, err := s.Member(guildId, discord.UserID(id))
+		if err != nil {
+			continue
+		}
+		if line, ok := lines[member.User.ID.String()]; ok {
+			return fmt.Errorf("%w: %s in lines %d and %d", domain.ErrDuplicateImportRow, id, line, change.Row.Line)
+		}
+		lines[member.User.ID.String()] = change.Row.Line
+		changes[i].New = true
+		changes[i].Player = models.Player{
+			DiscordId:   member.User.ID.String(),
+			DiscordName: member.User.Username,
+			GuildId:     guildId.String(),
+			Name:        memberName(member.Nick, member.User),
+		}
+	}
+	return nil
+}
+
+// importPreview stores the changes and shows them with buttons to apply or cancel the import.
+func importPreview(changes []models.ImportChange, locale i18n.Locale) *api.InteractionResponseData {
+	var changed, added, skipped int
+	lines := make([]string, 0, len(changes))
+	for _, change := range changes {
+		switch {
+		case change.Skipped():
+			skipped++
+			lines = append(lines, fmt.Sprintf("? %s (%s)", change.Row.Player, i18n.T(locale, i18n.ImportSkippedRow, change.Row.Line)))
+		case change.New:
+			added++
+			lines = append(lines, fmt.Sprintf("+ %s: %s", change.Player.Name, formatAmount(change.Row.Amount)))
+		case !change.Player.Active:
+			// the player left the board, but kept the debt
+			added++
+			lines = append(
+				lines,
+				fmt.Sprintf(
+					"+ %s: %s → %s",
+					change.Player.Name,
+					formatAmount(change.Player.Debt.Amount),
+					formatAmount(change.Row.Amount),
+				),
+			)
+		case change.Player.Debt.Amount != change.Row.Amount:
+			changed++
+			lines = append(
+				lines,
+				fmt.Sprintf(
+					"~ %s: %s → %s",
+					change.Player.Name,
+					formatAmount(change.Player.Debt.Amount),
+					formatAmount(change.Row.Amount),
+				),
+			)
+		default:
+			lines = append(lines, fmt.Sprintf("= %s: %s", change.Player.Name, formatAmount(change.Row.Amount)))
+		}
+	}
+	if changed+added == 0 {
+		return ephemeralMessage(i18n.T(locale, i18n.ImportNothing, skipped))
+	}
+
+	key := uuid.NewString()
+	pendingImports.Store(key, changes)
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(i18n.T(locale, i18n.ImportPreview, changed, added, skipped)),
+		Embeds: &[]discord.Embed{
+			{
+				Description: "```diff\n" + truncate(strings.Join(lines, "\n"), MaxImportPreviewLength) + "\n```",
+			},
+		},
+		Components: &discord.ContainerComponents{
+			&discord.ActionRowComponent{
+				&discord.ButtonComponent{
+					Style:    discord.SecondaryButtonStyle(),
+					CustomID: discord.ComponentID(ComponentIdCancelImport + "||" + key),
+					Label:    i18n.T(locale, i18n.Cancel),
+				},
+				&discord.ButtonComponent{
+					Style:    discord.DangerButtonStyle(),
+					CustomID: discord.ComponentID(ComponentIdConfirmImport + "||" + key),
+					Label:    i18n.T(locale, i18n.Confirm),
+				},
+			},
+		},
+		Flags: discord.EphemeralMessage,
+	}
+}
+
+// handleImportDecision applies or cancels the import of the preview the buttons belong to.
+func handleImportDecision(
+	ctx context.Context,
+	s *state.State,
+	service domain.Service,
+	authorizer *Authorizer,
+	event *gateway.InteractionCreateEvent,
+	customId string,
+) {
+	locale := interactionLocale(ctx, service, &event.InteractionEvent)
+	if !authorizer.IsAdmin(ctx, &event.InteractionEvent) {
+		log.Warn().Msgf("rejected import of non-admin %s in guild %s", event.SenderID(), event.GuildID)
+		respondEphemeral(s, event, i18n.T(locale, i18n.NotAllowed))
+		return
+	}
+	prefix, key, _ := strings.Cut(customId, "||")
+	changes, ok := pendingImports.LoadAndRemove(key)
+	if !ok {
+		respondWithPromptExpired(s, event, locale)
+		return
+	}
+	if prefix == ComponentIdCancelImport {
+		updateImportPreview(s, event, i18n.T(locale, i18n.ImportCancelled))
+		return
+	}
+
+	sender := senderName(&event.InteractionEvent)
+	imported := 0
+	for i, change := range changes {
+		changes[i].Description = fmt.Sprintf(JournalDescriptionImport, sender)
+		if change.Row.Note != "" {
+			changes[i].Description = fmt.Sprintf(JournalDescriptionReason, changes[i].Description, change.Row.Note)
+		}
+		if !change.Skipped() {
+			imported++
+		}
+	}
+	err := service.Import(ctx, event.GuildID.String(), changes)
+	if err != nil {
+		log.Error().Msgf("could not import: %s", err)
+		updateImportPreview(s, event, i18n.T(locale, i18n.ImportFailed))
+		return
+	}
+	log.Info().Msgf("imported %d rows in guild %s", imported, event.GuildID)
+	scheduleDebtsMessageUpdate(s, service, event.GuildID.String())
+	updateImportPreview(s, event, i18n.T(locale, i18n.ImportApplied, imported))
+}
+
+func updateImportPreview(s *state.State, event *gateway.InteractionCreateEvent, content string) {
+	err := s.RespondInteraction(
+		event.ID, event.Token, api.InteractionResponse{
+			Type: api.UpdateMessage,
+			Data: &api.InteractionResponseData{
+				Content:    option.NewNullableString(content),
+				Embeds:     &[]discord.Embed{},
+				Components: &discord.ContainerComponents{},
+			},
+		},
+	)
+	if err != nil {
+		log.Error().Msgf("could not respond to interaction: %s", err)
+	}
+}
@@ -0,0 +1,106 @@
+package command
+
+import (
+	"errors"
+	"reflect"
+	"slash10k/pkg/i18n"
+	"slash10k/pkg/models"
+	"strings"
+	"testing"
+)
+
+func Test_parseImport(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		want    []models.ImportRow
+		wantErr i18n.Key
+	}{
+		{
+			name: "without header",
+			file: "123456789012345678,10k,old sheet\ntorfstack, 2.5k\n",
+			want: []models.ImportRow{
+				{Line: 1, Player: "123456789012345678", Amount: 10000, Note: "old sheet"},
+				{Line: 2, Player: "torfstack", Amount: 2500},
+			},
+		},
+		{
+			name: "exported balances",
+			file: strings.Join(BalanceColumns, ",") + "\n" +
+				"123456789012345678,torfstack,30000,0,2024-03-01T18:00:00Z,true\n" +
+				",neruh,0,0,2024-03-01T18:00:00Z,false\n",
+			want: []models.ImportRow{
+				{Line: 2, Player: "123456789012345678", Amount: 30000},
+				{Line: 3, Player: "neruh", Amount: 0},
+			},
+		},
+		{
+			name: "header with note",
+			file: "Note,Name,Balance\n\"paid, mostly\",torfstack,500\n",
+			want: []models.ImportRow{{Line: 2, Player: "torfstack", Amount: 500, Note: "paid, mostly"}},
+		},
+		{
+			name: "escaped formula",
+			file: "name,balance\n'=torfstack,500\n'neruh,0\n",
+			want: []models.ImportRow{
+				{Line: 2, Player: "=torfstack", Amount: 500},
+				{Line: 3, Player: "'neruh", Amount: 0},
+			},
+		},
+		{name: "negative balance", file: "name,balance\ntorfstack,-500\n", wantErr: i18n.ImportInvalidRow},
+		{name: "header without balance", file: "name,gold\ntorfstack,10k\n", wantErr: i18n.ImportInvalidHeader},
+		{name: "row without balance", file: "torfstack,10k\nneruh\n", wantErr: i18n.ImportInvalidRow},
+		{name: "row without player", file: "torfstack,10k\n,5k\n", wantErr: i18n.ImportInvalidRow},
+		{name: "only a header", file: "name,balance\n", wantErr: i18n.ImportEmpty},
+		{name: "empty", file: "", wantErr: i18n.ImportEmpty},
+		{name: "not csv", file: "torfstack,\"10k\n", wantErr: i18n.ImportInvalidFile},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				rows, err := parseImport(strings.NewReader(tt.file))
+				if tt.wantErr != "" {
+					var fileErr importFileError
+					if !errors.As(err, &fileErr) || fileErr.key != tt.wantErr {
+						t.Fatalf("parseImport() error = %v, want %s", err, tt.wantErr)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("parseImport() error = %v", err)
+				}
+				if !reflect.DeepEqual(rows, tt.want) {
+					t.Errorf("parseImport() = %v, want %v", rows, tt.want)
+				}
+			},
+		)
+	}
+}
+
+func Test_importPreview(t *testing.T) {
+	changes := []models.ImportChange{
+		{Row: models.ImportRow{Line: 1, Amount: 5000}, Player: models.Player{DiscordId: "1", Name: "a", Active: true}},
+		{Row: models.ImportRow{Line: 2, Amount: 0}, Player: models.Player{DiscordId: "2", Name: "b", Active: true}},
+		{Row: models.ImportRow{Line: 3, Amount: 5000}, Player: models.Player{DiscordId: "3", Name: "c"}, New: true},
+		{Row: models.ImportRow{Line: 4, Player: "d"}},
+		{
+			Row:    models.ImportRow{Line: 5, Amount: 5000},
+			Player: models.Player{DiscordId: "5", Name: "e", Debt: models.Debt{Amount: 20000}},
+		},
+	}
+	data := importPreview(changes, i18n.DefaultLocale)
+	if want := i18n.T(i18n.DefaultLocale, i18n.ImportPreview, 1, 2, 1); data.Content.Val != want {
+		t.Errorf("Expected %q, got %q", want, data.Content.Val)
+	}
+	if want := "+ e: " + formatAmount(20000) + " → " + formatAmount(5000); !strings.Contains((*data.Embeds)[0].Description, want) {
+		t.Errorf("Expected the reactivated player as %q, got %s", want, (*data.Embeds)[0].Description)
+	}
+	if data.Components == nil || len(*data.Components) != 1 {
+		t.Fatalf("Expected buttons to confirm or cancel the import")
+	}
+
+	data = importPreview(changes[1:2], i18n.DefaultLocale)
+	if data.Components != nil {
+		t.Errorf("Expected no buttons without changes, got %v", data.Components)
+	}
+}
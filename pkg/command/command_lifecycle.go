@@ -0,0 +1,160 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/rs/zerolog/log"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/i18n"
+	"slash10k/pkg/utils"
+	"time"
+)
+
+// OrphanedGuildPurgeInterval is how often the data of guilds that removed the bot is checked for purging.
+const OrphanedGuildPurgeInterval = time.Hour
+
+// knownGuilds are the guilds the bot was already part of when it connected, a GuildCreateEvent
+// for them is sent on every connect and does not mean that the bot was added.
+var knownGuilds = utils.NewSyncSet[discord.GuildID]()
+
+func registerLifecycleHandlers(s *state.State, service domain.Service) {
+	s.AddHandler(
+		func(event *gateway.ReadyEvent) {
+			for _, guild := range event.Guilds {
+				knownGuilds.Add(guild.ID)
+			}
+			go markRemovedGuildsOrphaned(context.Background(), service, event.Guilds)
+		},
+	)
+	s.AddHandler(
+		func(event *gateway.GuildCreateEvent) {
+			if event.Unavailable {
+				return
+			}
+			added := !knownGuilds.Contains(event.ID)
+			knownGuilds.Add(event.ID)
+			// sent for every guild on every connect, the database must not hold up the gateway
+			go func(guild discord.Guild) {
+				ctx := context.Background()
+				err := service.UnmarkGuildOrphaned(ctx, guild.ID.String())
+				if err != nil {
+					log.Error().Msgf("could not unmark guild %s as orphaned: %s", guild.ID, err)
+				}
+				if !added {
+					return
+				}
+				log.Info().Msgf("added to guild %s", guild.ID)
+				sendOnboardingMessage(ctx, s, service, guild)
+			}(event.Guild)
+		},
+	)
+	s.AddHandler(
+		func(event *gateway.GuildDeleteEvent) {
+			// an unavailable guild is an outage, the bot is still part of it
+			if event.Unavailable {
+				return
+			}
+			knownGuilds.Remove(event.ID)
+			log.Info().Msgf("removed from guild %s, marking its data as orphaned", event.ID)
+			err := service.MarkGuildOrphaned(context.Background(), event.ID.String())
+			if err != nil {
+				log.Error().Msgf("could not mark guild %s as orphaned: %s", event.ID, err)
+			}
+		},
+	)
+}
+
+func sendOnboardingMessage(ctx context.Context, s *state.State, service domain.Service, guild discord.Guild) {
+	_, err := service.GetBotSetup(ctx, guild.ID.String())
+	if err == nil {
+		return
+	} else if !errors.Is(err, domain.ErrBotSetupDoesNotExist) {
+		log.Error().Msgf("could not get bot setup: %s", err)
+		return
+	}
+	if !guild.SystemChannelID.IsValid() {
+		log.Info().Msgf("guild %s has no system channel, skipping onboarding message", guild.ID)
+		return
+	}
+	locale := i18n.FromDiscord(discord.Language(guild.PreferredLocale))
+	_, err = s.SendMessage(guild.SystemChannelID, i18n.T(locale, i18n.OnboardingMessage))
+	if err != nil {
+		log.Warn().Msgf("could not send onboarding message to guild %s: %s", guild.ID, err)
+	}
+}
+
+// markRemovedGuildsOrphaned catches up on removals from guilds while the bot was not connected,
+// every set up guild that is not part of the ready event has removed the bot.
+func markRemovedGuildsOrphaned(ctx context.Context, service domain.Service, guilds []gateway.GuildCreateEvent) {
+	botSetups, err := service.GetAllBotSetups(ctx)
+	if err != nil {
+		log.Error().Msgf("cannot get bot setups: %s", err)
+		return
+	}
+	joined := make(map[string]bool, len(guilds))
+	for _, guild := range guilds {
+		joined[guild.ID.String()] = true
+	}
+	for _, botSetup := range botSetups {
+		if joined[botSetup.GuildId] {
+			continue
+		}
+		log.Info().Msgf("removed from guild %s while disconnected, marking its data as orphaned", botSetup.GuildId)
+		err = service.MarkGuildOrphaned(ctx, botSetup.GuildId)
+		if err != nil {
+			log.Error().Msgf("could not mark guild %s as orphaned: %s", botSetup.GuildId, err)
+		}
+	}
+}
+
+// PurgeOrphanedGuilds periodically deletes the data of guilds that removed the bot
+// longer than the retention period ago. It blocks until the context is done.
+func PurgeOrphanedGuilds(
+	ctx context.Context,
+	service domain.Service,
+	lookup domain.MessageLookup,
+	retention time.Duration,
+) {
+	ticker := time.NewTicker(OrphanedGuildPurgeInterval)
+	defer ticker.Stop()
+	for {
+		purgeOrphanedGuilds(ctx, service, lookup, time.Now().Add(-retention))
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func purgeOrphanedGuilds(
+	ctx context.Context,
+	service domain.Service,
+	lookup domain.MessageLookup,
+	orphanedBefore time.Time,
+) {
+	guildIds, err := service.GetOrphanedGuilds(ctx, orphanedBefore)
+	if err != nil {
+		log.Error().Msgf("cannot get orphaned guilds: %s", err)
+		return
+	}
+	for _, guildId := range guildIds {
+		botSetup, err := service.GetBotSetup(ctx, guildId)
+		if err != nil && !errors.Is(err, domain.ErrBotSetupDoesNotExist) {
+			log.Error().Msgf("cannot get bot setup: %s", err)
+			continue
+		}
+		err = service.PurgeGuild(ctx, guildId)
+		if err != nil {
+			log.Error().Msgf("cannot purge guild %s: %s", guildId, err)
+			continue
+		}
+		if botSetup != nil {
+			lookup.RemoveSetup(*botSetup)
+		}
+		log.Info().Msgf("purged data of orphaned guild %s", guildId)
+	}
+}
@@ -0,0 +1,61 @@
+package command
+
+import (
+	"context"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/rs/zerolog/log"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/i18n"
+	"slash10k/pkg/models"
+	"strings"
+)
+
+// interactionLocale is the language of the guild if an admin chose one, otherwise the language
+// of the client of the user that caused the interaction.
+func interactionLocale(ctx context.Context, service domain.Service, event *discord.InteractionEvent) i18n.Locale {
+	if event.GuildID.IsValid() {
+		settings, err := service.GetGuildSettings(ctx, event.GuildID.String())
+		if err == nil {
+			return settingsLocale(*settings, event)
+		}
+		log.Warn().Msgf("cannot get guild settings for locale: %s", err)
+	}
+	return i18n.FromDiscord(event.Locale)
+}
+
+// guildLocale is the language of messages that are not a response to a single user, like the board.
+// It is the language an admin chose, otherwise the preferred language of the guild.
+func guildLocale(s *state.State, settings models.GuildSettings) i18n.Locale {
+	if settings.Locale != "" {
+		return i18n.Locale(settings.Locale)
+	}
+	guildId, err := discord.ParseSnowflake(settings.GuildId)
+	if err != nil {
+		return i18n.DefaultLocale
+	}
+	guild, err := s.Guild(discord.GuildID(guildId))
+	if err != nil {
+		log.Warn().Msgf("cannot get guild for locale: %s", err)
+		return i18n.DefaultLocale
+	}
+	return i18n.FromDiscord(discord.Language(guild.PreferredLocale))
+}
+
+// boardTitle is the title of the board with the penalty amount filled in.
+func boardTitle(settings models.GuildSettings, locale i18n.Locale) string {
+	title := settings.BoardTitle
+	if title == models.DefaultBoardTitle {
+		title = i18n.T(locale, i18n.BoardTitle)
+	}
+	return strings.ReplaceAll(title, models.BoardTitleAmount, formatAmount(settings.PenaltyAmount))
+}
+
+// settingsLocale is the language of the guild settings, or the language of the client
+// of the user that caused the interaction if the guild has none.
+func settingsLocale(settings models.GuildSettings, event *discord.InteractionEvent) i18n.Locale {
+	if settings.Locale != "" {
+		return i18n.Locale(settings.Locale)
+	}
+	return i18n.FromDiscord(event.Locale)
+}
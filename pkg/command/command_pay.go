@@ -0,0 +1,268 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/rs/zerolog/log"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/i18n"
+	"slash10k/pkg/models"
+	"strconv"
+	"strings"
+)
+
+const (
+	ComponentIdPayModal           = "PAY_MODAL"
+	ComponentIdPayAmount          = "PAY_AMOUNT"
+	ComponentPlaceholderPayAmount = "10k, 25000, 1.5k"
+	ComponentIdApprovePayment     = "APPROVE_PAYMENT"
+	ComponentIdRejectPayment      = "REJECT_PAYMENT"
+)
+
+func respondWithPayModal(
+	ctx context.Context,
+	s *state.State,
+	service domain.Service,
+	event *gateway.InteractionCreateEvent,
+) {
+	locale := interactionLocale(ctx, service, &event.InteractionEvent)
+	player, err := service.GetPlayer(ctx, event.SenderID().String(), event.GuildID.String())
+	if err != nil {
+		log.Error().Msgf("could not get player: %s", err)
+		respondEphemeral(s, event, i18n.T(locale, i18n.PayNotRegistered))
+		return
+	}
+	if player.Debt.Amount <= 0 {
+		respondEphemeral(s, event, i18n.T(locale, i18n.PayNoDebts))
+		return
+	}
+
+	err = s.RespondInteraction(
+		event.ID, event.Token, api.InteractionResponse{
+			Type: api.ModalResponse,
+			Data: &api.InteractionResponseData{
+				CustomID: option.NewNullableString(ComponentIdPayModal),
+				Title:    option.NewNullableString(i18n.T(locale, i18n.Pay)),
+				Components: &discord.ContainerComponents{
+					&discord.ActionRowComponent{
+						&discord.TextInputComponent{
+							CustomID:    ComponentIdPayAmount,
+							Style:       discord.TextInputShortStyle,
+							Label:       i18n.T(locale, i18n.PayAmount),
+							Required:    true,
+							Value:       strconv.FormatInt(player.Debt.Amount, 10),
+							Placeholder: ComponentPlaceholderPayAmount,
+						},
+					},
+				},
+			},
+		},
+	)
+	if err != nil {
+		log.Error().Msgf("could not respond to interaction: %s", err)
+		return
+	}
+}
+
+func handlePayModal(
+	ctx context.Context,
+	s *state.State,
+	service domain.Service,
+	event *gateway.InteractionCreateEvent,
+	data *discord.ModalInteraction,
+) {
+	locale := interactionLocale(ctx, service, &event.InteractionEvent)
+	input, ok := data.Components.Find(ComponentIdPayAmount).(*discord.TextInputComponent)
+	if !ok {
+		log.Error().Msg("could not find amount in pay modal")
+		respondEphemeral(s, event, i18n.T(locale, i18n.PayReadAmountFailed))
+		return
+	}
+	amount, err := parseAmount(input.Value)
+	if err != nil {
+		log.Warn().Msgf("could not parse amount: %s", err)
+		respondEphemeral(s, event, i18n.T(locale, i18n.InvalidAmount, input.Value))
+		return
+	}
+
+	settings, err := service.GetGuildSettings(ctx, event.GuildID.String())
+	if err != nil {
+		log.Error().Msgf("could not get guild settings: %s", err)
+		respondEphemeral(s, event, i18n.T(locale, i18n.PayFailed))
+		return
+	}
+
+	// with a treasurer channel the payment only counts once a treasurer approved it
+	var payment *models.PendingPayment
+	if settings.TreasurerChannelId == "" {
+		err = service.PayDebt(ctx, event.SenderID().String(), event.GuildID.String(), amount)
+	} else {
+		payment, err = service.RequestPayment(ctx, event.SenderID().String(), event.GuildID.String(), amount)
+	}
+	switch {
+	case errors.Is(err, domain.ErrInvalidAmount):
+		respondEphemeral(s, event, i18n.T(locale, i18n.AmountNotPositive))
+		return
+	case errors.Is(err, domain.ErrAmountExceedsDebt):
+		respondEphemeral(s, event, i18n.T(locale, i18n.PayExceedsDebt))
+		return
+	case err != nil:
+		log.Error().Msgf("could not pay debt: %s", err)
+		respondEphemeral(s, event, i18n.T(locale, i18n.PayFailed))
+		return
+	}
+
+	if payment != nil {
+		err = reportPayment(s, *settings, *payment)
+		if err != nil {
+			log.Error().Msgf("could not report payment: %s", err)
+			_, err = service.RejectPayment(ctx, payment.GuildId, payment.Id)
+			if err != nil {
+				log.Error().Msgf("could not discard unreported payment: %s", err)
+			}
+			respondEphemeral(s, event, i18n.T(locale, i18n.PayReportFailed))
+			return
+		}
+		scheduleDebtsMessageUpdate(s, service, event.GuildID.String())
+		respondEphemeral(s, event, i18n.T(locale, i18n.PayPending, formatAmount(amount)))
+		return
+	}
+
+	scheduleDebtsMessageUpdate(s, service, event.GuildID.String())
+	respondEphemeral(s, event, i18n.T(locale, i18n.PayThanks, formatAmount(amount)))
+}
+
+// reportPayment posts the payment to the treasurer channel, with buttons to approve or reject it.
+func reportPayment(s *state.State, settings models.GuildSettings, payment models.PendingPayment) error {
+	channelId, err := discord.ParseSnowflake(settings.TreasurerChannelId)
+	if err != nil {
+		return fmt.Errorf("could not parse treasurer channel id: %w", err)
+	}
+	locale := guildLocale(s, settings)
+	_, err = s.SendMessageComplex(
+		discord.ChannelID(channelId), api.SendMessageData{
+			Content: i18n.T(locale, i18n.PaymentReported, payment.DiscordId, formatAmount(payment.Amount)),
+			Components: discord.ContainerComponents{
+				&discord.ActionRowComponent{
+					&discord.ButtonComponent{
+						Style:    discord.DangerButtonStyle(),
+						CustomID: paymentComponentId(ComponentIdRejectPayment, payment.Id),
+						Label:    i18n.T(locale, i18n.PaymentReject),
+					},
+					&discord.ButtonComponent{
+						Style:    discord.SuccessButtonStyle(),
+						CustomID: paymentComponentId(ComponentIdApprovePayment, payment.Id),
+						Label:    i18n.T(locale, i18n.PaymentApprove),
+					},
+				},
+			},
+			// the payer is told about the outcome in a direct message, not on every report
+			AllowedMentions: &api.AllowedMentions{},
+		},
+	)
+	return err
+}
+
+func paymentComponentId(prefix string, id int32) discord.ComponentID {
+	return discord.ComponentID(fmt.Sprintf("%s||%d", prefix, id))
+}
+
+// handlePaymentDecision approves or rejects a pending payment from the buttons in the treasurer channel.
+// The buttons are removed once the payment is decided, the payer is told in a direct message.
+func handlePaymentDecision(
+	ctx context.Context,
+	s *state.State,
+	service domain.Service,
+	authorizer *Authorizer,
+	event *gateway.InteractionCreateEvent,
+	customId string,
+) {
+	locale := interactionLocale(ctx, service, &event.InteractionEvent)
+	if !authorizer.IsAdmin(ctx, &event.InteractionEvent) {
+		log.Warn().Msgf("rejected payment decision of non-admin %s in guild %s", event.SenderID(), event.GuildID)
+		respondEphemeral(s, event, i18n.T(locale, i18n.NotAllowed))
+		return
+	}
+	prefix, value, _ := strings.Cut(customId, "||")
+	id, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		log.Error().Msgf("could not parse pending payment id: %s", err)
+		return
+	}
+	settings, err := service.GetGuildSettings(ctx, event.GuildID.String())
+	if err != nil {
+		log.Error().Msgf("could not get guild settings: %s", err)
+		respondEphemeral(s, event, i18n.T(locale, i18n.PaymentApproveFailed))
+		return
+	}
+
+	sender := senderName(&event.InteractionEvent)
+	outcome, direct := i18n.PaymentApproved, i18n.PaymentApprovedDirect
+	var payment *models.PendingPayment
+	if prefix == ComponentIdApprovePayment {
+		payment, err = service.ApprovePayment(
+			ctx,
+			event.GuildID.String(),
+			int32(id),
+			fmt.Sprintf(domain.JournalDescriptionApprovedPayment, sender),
+		)
+	} else {
+		outcome, direct = i18n.PaymentRejected, i18n.PaymentRejectedDirect
+		payment, err = service.RejectPayment(ctx, event.GuildID.String(), int32(id))
+	}
+	switch {
+	case errors.Is(err, domain.ErrPendingPaymentDoesNotExist):
+		respondEphemeral(s, event, i18n.T(locale, i18n.PaymentAlreadyHandled))
+		return
+	case errors.Is(err, domain.ErrAmountExceedsDebt):
+		// the debt was corrected since the payment was reported, the payment was rejected instead
+		log.Warn().Msgf("rejected payment %d that exceeds the debt: %s", id, err)
+		outcome, direct = i18n.PaymentTooHigh, i18n.PaymentTooHighDirect
+	case err != nil:
+		log.Error().Msgf("could not decide payment: %s", err)
+		respondEphemeral(s, event, i18n.T(locale, i18n.PaymentApproveFailed))
+		return
+	}
+	scheduleDebtsMessageUpdate(s, service, event.GuildID.String())
+
+	guildLanguage := guildLocale(s, *settings)
+	amount := formatAmount(payment.Amount)
+	err = s.RespondInteraction(
+		event.ID, event.Token, api.InteractionResponse{
+			Type: api.UpdateMessage,
+			Data: &api.InteractionResponseData{
+				Content:         option.NewNullableString(i18n.T(guildLanguage, outcome, amount, payment.DiscordId, sender)),
+				Components:      &discord.ContainerComponents{},
+				AllowedMentions: &api.AllowedMentions{},
+			},
+		},
+	)
+	if err != nil {
+		log.Error().Msgf("could not respond to interaction: %s", err)
+	}
+
+	payer, err := discord.ParseSnowflake(payment.DiscordId)
+	if err != nil {
+		log.Error().Msgf("could not parse payer id: %s", err)
+		return
+	}
+	sendDirectMessages(s, []discord.UserID{discord.UserID(payer)}, i18n.T(guildLanguage, direct, amount))
+}
+
+func respondEphemeral(s *state.State, event *gateway.InteractionCreateEvent, content string) {
+	err := s.RespondInteraction(
+		event.ID, event.Token, api.InteractionResponse{
+			Type: api.MessageInteractionWithSource,
+			Data: ephemeralMessage(content),
+		},
+	)
+	if err != nil {
+		log.Error().Msgf("could not respond to interaction: %s", err)
+	}
+}
@@ -0,0 +1,106 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/rs/zerolog/log"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/i18n"
+	"slash10k/pkg/models"
+	"strconv"
+	"strings"
+)
+
+// Guilds with more players than fit into the select menus of the debts message pick
+// players from an ephemeral message that pages through them instead.
+const (
+	ComponentIdPlayerPage = "PLAYER_PAGE"
+)
+
+func respondWithPlayerPage(
+	ctx context.Context,
+	s *state.State,
+	service domain.Service,
+	event *gateway.InteractionCreateEvent,
+	customId string,
+) {
+	page, err := strconv.Atoi(strings.TrimPrefix(customId, ComponentIdPlayerPage+"||"))
+	if err != nil || page < 0 {
+		log.Error().Msgf("malformed player page component id: %s", customId)
+		return
+	}
+	allPlayers, err := service.GetAllPlayers(ctx, event.GuildID.String())
+	if err != nil {
+		log.Error().Msgf("could not get all players: %s", err)
+		return
+	}
+	data := playerPage(allPlayers, page, interactionLocale(ctx, service, &event.InteractionEvent))
+
+	// The first page is opened from the debts message, all others replace the ephemeral page.
+	responseType := api.UpdateMessage
+	if event.Message == nil || event.Message.Flags&discord.EphemeralMessage == 0 {
+		responseType = api.MessageInteractionWithSource
+		data.Flags = discord.EphemeralMessage
+	}
+	err = s.RespondInteraction(
+		event.ID, event.Token, api.InteractionResponse{
+			Type: responseType,
+			Data: data,
+		},
+	)
+	if err != nil {
+		log.Error().Msgf("could not respond to interaction: %s", err)
+		return
+	}
+}
+
+func playerPage(allPlayers models.Players, page int, locale i18n.Locale) *api.InteractionResponseData {
+	allPlayers.SortByName()
+	pages := (len(allPlayers) + MaxSelectOptions - 1) / MaxSelectOptions
+	if page >= pages {
+		page = max(pages-1, 0)
+	}
+	if len(allPlayers) == 0 {
+		return &api.InteractionResponseData{
+			Content:    option.NewNullableString(i18n.T(locale, i18n.PlayersEmpty)),
+			Components: &discord.ContainerComponents{},
+		}
+	}
+
+	group := allPlayers[page*MaxSelectOptions : min((page+1)*MaxSelectOptions, len(allPlayers))]
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(i18n.T(locale, i18n.PlayersPage, page+1, pages)),
+		Components: &discord.ContainerComponents{
+			&discord.ActionRowComponent{
+				&discord.StringSelectComponent{
+					Options:     playerSelectOptions(group),
+					CustomID:    ComponentIdSelectPlayer,
+					Placeholder: i18n.T(locale, i18n.SelectPlayer),
+				},
+			},
+			&discord.ActionRowComponent{
+				&discord.ButtonComponent{
+					Style:    discord.SecondaryButtonStyle(),
+					CustomID: playerPageComponentId(page - 1),
+					Label:    i18n.T(locale, i18n.Previous),
+					Disabled: page == 0,
+				},
+				&discord.ButtonComponent{
+					Style:    discord.SecondaryButtonStyle(),
+					CustomID: playerPageComponentId(page + 1),
+					Label:    i18n.T(locale, i18n.Next),
+					Disabled: page >= pages-1,
+				},
+			},
+		},
+	}
+}
+
+func playerPageComponentId(page int) discord.ComponentID {
+	return discord.ComponentID(fmt.Sprintf("%s||%d", ComponentIdPlayerPage, page))
+}
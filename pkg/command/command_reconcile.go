@@ -0,0 +1,132 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/rs/zerolog/log"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/models"
+	"time"
+)
+
+// reconcileRegistrations catches up on reactions to the registration messages that were
+// missed while the bot was not connected to the gateway.
+func reconcileRegistrations(ctx context.Context, s *state.State, service domain.Service) {
+	botSetups, err := service.GetAllBotSetups(ctx)
+	if err != nil {
+		log.Error().Msgf("cannot get bot setups for reconciliation: %s", err)
+		return
+	}
+	for _, botSetup := range botSetups {
+		added, deactivated, err := reconcileRegistration(ctx, s, service, botSetup)
+		if err != nil {
+			log.Error().Msgf("cannot reconcile registrations of guild %s: %s", botSetup.GuildId, err)
+			continue
+		}
+		log.Info().Msgf(
+			"reconciled registrations of guild %s: %d added, %d deactivated",
+			botSetup.GuildId,
+			added,
+			deactivated,
+		)
+		scheduleDebtsMessageUpdate(s, service, botSetup.GuildId)
+	}
+}
+
+func reconcileRegistration(
+	ctx context.Context,
+	s *state.State,
+	service domain.Service,
+	botSetup models.BotSetup,
+) (int, int, error) {
+	channelId, _ := botSetupToDiscordTypes(botSetup)
+	registrationMessageId, err := discord.ParseSnowflake(botSetup.RegistrationMessageId)
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not parse registration message id: %w", err)
+	}
+	settings, err := service.GetGuildSettings(ctx, botSetup.GuildId)
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not get guild settings: %w", err)
+	}
+	reactors, err := s.Reactions(
+		channelId,
+		discord.MessageID(registrationMessageId),
+		discord.APIEmoji(settings.RegistrationEmoji),
+		0,
+	)
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not get reactions: %w", err)
+	}
+	return reconcileReactions(ctx, service, botSetup, reactors, time.Now())
+}
+
+// reconcileReactions adds the users that reacted to the registration message and deactivates the active
+// players that did not. While reactions are awaited, see models.BotSetup, players are only deactivated
+// once all of them reacted again or the grace period is over.
+func reconcileReactions(
+	ctx context.Context,
+	service domain.Service,
+	botSetup models.BotSetup,
+	reactors []discord.User,
+	now time.Time,
+) (int, int, error) {
+	activePlayers, err := service.GetAllPlayers(ctx, botSetup.GuildId)
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not get all players: %w", err)
+	}
+
+	registered := make(map[string]bool, len(activePlayers))
+	for _, p := range activePlayers {
+		registered[p.DiscordId] = true
+	}
+	reacted := make(map[string]bool, len(reactors))
+	added, deactivated := 0, 0
+	for _, user := range reactors {
+		if user.Bot {
+			continue
+		}
+		reacted[user.ID.String()] = true
+		if registered[user.ID.String()] {
+			continue
+		}
+		err = service.AddPlayer(ctx, user.ID.String(), user.Username, botSetup.GuildId, memberName("", user))
+		if err == nil {
+			added++
+		} else if !errors.Is(err, domain.ErrPlayerAlreadyExists) {
+			return added, deactivated, fmt.Errorf("could not add player: %w", err)
+		}
+	}
+	missing := make([]models.Player, 0)
+	for _, p := range activePlayers {
+		if !reacted[p.DiscordId] {
+			missing = append(missing, p)
+		}
+	}
+	if len(missing) > 0 && botSetup.AwaitingReactions(now) {
+		// the reactions were lost with the previous message or emoji, not with the players
+		log.Info().Msgf(
+			"registration message of guild %s still lacks the reactions of %d players, keeping them",
+			botSetup.GuildId,
+			len(missing),
+		)
+		return added, deactivated, nil
+	}
+	if botSetup.AwaitingReactionsUntil != 0 {
+		err = service.SetAwaitingReactions(ctx, botSetup.GuildId, false)
+		if err != nil {
+			return added, deactivated, fmt.Errorf("could not clear awaiting reactions: %w", err)
+		}
+	}
+	for _, p := range missing {
+		err = service.DeactivatePlayer(ctx, p.DiscordId, botSetup.GuildId)
+		if err == nil {
+			deactivated++
+		} else if !errors.Is(err, domain.ErrPlayerDoesNotExist) {
+			return added, deactivated, fmt.Errorf("could not deactivate player: %w", err)
+		}
+	}
+	return added, deactivated, nil
+}
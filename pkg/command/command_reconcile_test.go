@@ -0,0 +1,125 @@
+package command
+
+import (
+	"context"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"go.uber.org/mock/gomock"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/models"
+	"slash10k/pkg/testutil"
+	sqlc "slash10k/sql/gen"
+	"testing"
+	"time"
+)
+
+func Test_reconcileReactions(t *testing.T) {
+	now := time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name            string
+		awaitingUntil   time.Time
+		wantDeactivated int
+		wantCleared     bool
+	}{
+		{name: "not awaiting reactions", wantDeactivated: 1},
+		{name: "awaiting reactions", awaitingUntil: now.Add(time.Hour)},
+		{name: "grace period is over", awaitingUntil: now.Add(-time.Hour), wantDeactivated: 1, wantCleared: true},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				c := gomock.NewController(t)
+				mockDb, mockQueries := testutil.QueriesMock(c)
+				mockQueries.EXPECT().
+					GetAllPlayers(gomock.Any(), testutil.TestGuildIdString()).
+					Return(
+						[]sqlc.GetAllPlayersRow{
+							{Player: sqlc.Player{ID: 1, DiscordID: "1", GuildID: testutil.TestGuildIdString()}},
+							{Player: sqlc.Player{ID: 2, DiscordID: "2", GuildID: testutil.TestGuildIdString()}},
+						}, nil,
+					)
+				if tt.wantCleared {
+					mockQueries.EXPECT().
+						SetBotSetupAwaitingReactions(
+							gomock.Any(),
+							sqlc.SetBotSetupAwaitingReactionsParams{GuildID: testutil.TestGuildIdString()},
+						)
+				}
+				if tt.wantDeactivated > 0 {
+					mockQueries.EXPECT().DoesPlayerExist(gomock.Any(), gomock.Any()).Return(true, nil)
+					mockQueries.EXPECT().GetIdOfPlayer(gomock.Any(), gomock.Any()).Return(int32(1), nil)
+					mockQueries.EXPECT().
+						SetPlayerActive(gomock.Any(), sqlc.SetPlayerActiveParams{ID: 1, Active: false})
+				}
+
+				botSetup := models.BotSetup{GuildId: testutil.TestGuildIdString()}
+				if !tt.awaitingUntil.IsZero() {
+					botSetup.AwaitingReactionsUntil = tt.awaitingUntil.Unix()
+				}
+				added, deactivated, err := reconcileReactions(
+					context.Background(),
+					domain.NewSlashTenK(mockDb),
+					botSetup,
+					[]discord.User{{ID: 2}, {ID: 3, Bot: true}},
+					now,
+				)
+				if err != nil {
+					t.Fatalf("reconcileReactions() error = %v", err)
+				}
+				if added != 0 || deactivated != tt.wantDeactivated {
+					t.Errorf("reconcileReactions() = %d, %d, want 0, %d", added, deactivated, tt.wantDeactivated)
+				}
+			},
+		)
+	}
+}
+
+func Test_reconcileReactionsAfterImport(t *testing.T) {
+	c := gomock.NewController(t)
+	mockDb, mockQueries := testutil.QueriesMock(c)
+	service := domain.NewSlashTenK(mockDb)
+	imported := sqlc.Player{ID: 1, DiscordID: "1", GuildID: testutil.TestGuildIdString()}
+	mockQueries.EXPECT().DoesPlayerExist(gomock.Any(), gomock.Any()).Return(true, nil)
+	mockQueries.EXPECT().
+		GetPlayer(gomock.Any(), gomock.Any()).
+		Return(sqlc.GetPlayerRow{Player: imported}, nil)
+	mockQueries.EXPECT().SetPlayerActive(gomock.Any(), sqlc.SetPlayerActiveParams{ID: 1, Active: true})
+	mockQueries.EXPECT().
+		GetPlayerForUpdate(gomock.Any(), gomock.Any()).
+		Return(sqlc.GetPlayerForUpdateRow{Player: imported, Debt: sqlc.Debt{UserID: 1, Amount: 20000}}, nil)
+	mockQueries.EXPECT().SetDebt(gomock.Any(), gomock.Any())
+	mockQueries.EXPECT().AddJournalEntry(gomock.Any(), gomock.Any())
+	botSetup := models.BotSetup{GuildId: testutil.TestGuildIdString()}
+	mockQueries.EXPECT().
+		SetBotSetupAwaitingReactions(gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(_ context.Context, params sqlc.SetBotSetupAwaitingReactionsParams) error {
+				botSetup.AwaitingReactionsUntil = params.AwaitingReactionsUntil.Time.Unix()
+				return nil
+			},
+		)
+
+	err := service.Import(
+		context.Background(), testutil.TestGuildIdString(), []models.ImportChange{
+			{
+				Row:    models.ImportRow{Line: 1, Player: "1", Amount: 5000},
+				Player: models.Player{DiscordId: "1", Debt: models.Debt{Amount: 20000}},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	imported.Active = true
+	mockQueries.EXPECT().
+		GetAllPlayers(gomock.Any(), testutil.TestGuildIdString()).
+		Return([]sqlc.GetAllPlayersRow{{Player: imported}}, nil)
+	// the imported player did not react to the registration message, but is not deactivated
+	_, deactivated, err := reconcileReactions(context.Background(), service, botSetup, nil, time.Now())
+	if err != nil {
+		t.Fatalf("reconcileReactions() error = %v", err)
+	}
+	if deactivated != 0 {
+		t.Errorf("Expected the imported player to stay active, got %d deactivated", deactivated)
+	}
+}
@@ -0,0 +1,110 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/rs/zerolog/log"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/i18n"
+	"slash10k/pkg/models"
+	"slash10k/pkg/scheduler"
+	"time"
+)
+
+// ReminderCatchUp is how late reminders are still sent, e.g. when the bot was down at the time
+// they were due. Players whose debt exceeds the threshold within that time are reminded as well.
+const ReminderCatchUp = time.Hour
+
+func SetReminders(service domain.Service, enabled bool) func(
+	ctx context.Context,
+	data cmdroute.CommandData,
+) *api.InteractionResponseData {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		guildId := data.Event.GuildID
+		log.Info().Msgf("set reminders called for guild %s", guildId)
+		locale := interactionLocale(ctx, service, data.Event)
+
+		err := service.SetRemindersEnabled(ctx, data.Event.SenderID().String(), guildId.String(), enabled)
+		if errors.Is(err, domain.ErrPlayerDoesNotExist) {
+			return ephemeralMessage(i18n.T(locale, i18n.PayNotRegistered))
+		} else if err != nil {
+			log.Error().Msgf("cannot set reminders: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.RemindersFailed))
+		}
+
+		if enabled {
+			return ephemeralMessage(i18n.T(locale, i18n.RemindersOn))
+		}
+		return ephemeralMessage(i18n.T(locale, i18n.RemindersOff))
+	}
+}
+
+// RemindDebtors is a job that reminds players of their debt in a direct message, on the weekday and at
+// the time the guild chose. Only players whose debt exceeds the threshold of the guild are reminded.
+func RemindDebtors(s *state.State, service domain.Service) scheduler.Job {
+	return func(ctx context.Context, now time.Time) {
+		guilds, err := service.GetGuildSettingsWithReminders(ctx)
+		if err != nil {
+			log.Error().Msgf("cannot get guilds with reminders: %s", err)
+			return
+		}
+		for _, settings := range guilds {
+			remindDebtors(ctx, s, service, settings, now)
+		}
+	}
+}
+
+func remindDebtors(
+	ctx context.Context,
+	s *state.State,
+	service domain.Service,
+	settings models.GuildSettings,
+	now time.Time,
+) {
+	location, err := time.LoadLocation(settings.Timezone)
+	if err != nil {
+		log.Error().Msgf("cannot load timezone of guild %s: %s", settings.GuildId, err)
+		return
+	}
+	schedule := scheduler.Weekly{Weekday: settings.ReminderWeekday, Minute: settings.ReminderTime, Location: location}
+	dueAt, due := schedule.Due(now, ReminderCatchUp)
+	if !due {
+		return
+	}
+
+	players, err := service.ClaimDebtReminders(ctx, settings.GuildId, settings.ReminderThreshold, dueAt)
+	if err != nil {
+		log.Error().Msgf("cannot claim reminders of guild %s: %s", settings.GuildId, err)
+		return
+	}
+	if len(players) == 0 {
+		return
+	}
+
+	locale := guildLocale(s, settings)
+	guildName := settings.GuildId
+	guildId, err := discord.ParseSnowflake(settings.GuildId)
+	if err == nil {
+		guild, err := s.Guild(discord.GuildID(guildId))
+		if err == nil {
+			guildName = guild.Name
+		}
+	}
+	for _, player := range players {
+		userId, err := discord.ParseSnowflake(player.DiscordId)
+		if err != nil {
+			log.Error().Msgf("cannot parse id of player %s: %s", player.DiscordId, err)
+			continue
+		}
+		sendDirectMessages(
+			s,
+			[]discord.UserID{discord.UserID(userId)},
+			i18n.T(locale, i18n.ReminderMessage, formatAmount(player.Debt.Amount), guildName),
+		)
+	}
+	log.Info().Msgf("reminded %d players of guild %s", len(players), settings.GuildId)
+}
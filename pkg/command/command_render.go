@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"github.com/diamondburned/arikawa/v3/state"
+	"slash10k/pkg/domain"
+	"sync"
+	"time"
+)
+
+// DebtsRenderDelay is the time changes are collected before the debts message is edited.
+const DebtsRenderDelay = time.Second
+
+var (
+	debtsRenderer = newRenderScheduler(DebtsRenderDelay)
+	// renderDebtsMessage is what the scheduled renders of the debts message run, tests replace it.
+	renderDebtsMessage = updateDebtsMessage
+)
+
+// scheduleDebtsMessageUpdate edits the debts message of the guild after DebtsRenderDelay, so
+// that a wave of changes results in a single edit instead of running into rate limits.
+func scheduleDebtsMessageUpdate(state *state.State, service domain.Service, guildId string) {
+	debtsRenderer.Schedule(
+		guildId, func(ctx context.Context) {
+			renderDebtsMessage(ctx, state, service, guildId)
+		},
+	)
+}
+
+// renderScheduler coalesces render requests per key. Requests arriving within the delay are
+// rendered once, renders of the same key never run concurrently, and a request arriving during
+// a render causes another render afterward, so the latest state is always rendered.
+type renderScheduler struct {
+	mu     sync.Mutex
+	delay  time.Duration
+	guilds map[string]*guildRender
+}
+
+type guildRender struct {
+	render    func(ctx context.Context)
+	scheduled bool
+	running   bool
+}
+
+func newRenderScheduler(delay time.Duration) *renderScheduler {
+	return &renderScheduler{delay: delay, guilds: make(map[string]*guildRender)}
+}
+
+func (r *renderScheduler) Schedule(key string, render func(ctx context.Context)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	g, ok := r.guilds[key]
+	if !ok {
+		g = &guildRender{}
+		r.guilds[key] = g
+	}
+	g.render = render
+	if g.scheduled {
+		return
+	}
+	g.scheduled = true
+	if g.running {
+		// started as soon as the running render is done
+		return
+	}
+	time.AfterFunc(r.delay, func() { r.run(key) })
+}
+
+func (r *renderScheduler) run(key string) {
+	r.mu.Lock()
+	g := r.guilds[key]
+	g.scheduled = false
+	g.running = true
+	render := g.render
+	r.mu.Unlock()
+
+	render(context.Background())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	g.running = false
+	if g.scheduled {
+		time.AfterFunc(r.delay, func() { r.run(key) })
+		return
+	}
+	delete(r.guilds, key)
+}
@@ -0,0 +1,86 @@
+package command
+
+import (
+	"context"
+	"github.com/diamondburned/arikawa/v3/state"
+	"slash10k/pkg/domain"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_renderScheduler(t *testing.T) {
+	t.Run(
+		"coalesces rapid requests into a single render", func(t *testing.T) {
+			r := newRenderScheduler(20 * time.Millisecond)
+			var renders atomic.Int32
+			for range 20 {
+				r.Schedule("guild", func(ctx context.Context) { renders.Add(1) })
+			}
+			time.Sleep(100 * time.Millisecond)
+			if renders.Load() != 1 {
+				t.Fatalf("Expected 1 render, got %d", renders.Load())
+			}
+		},
+	)
+	t.Run(
+		"renders again when requested during a render", func(t *testing.T) {
+			r := newRenderScheduler(10 * time.Millisecond)
+			var renders, running, overlaps atomic.Int32
+			started := make(chan struct{}, 1)
+			render := func(ctx context.Context) {
+				if running.Add(1) > 1 {
+					overlaps.Add(1)
+				}
+				renders.Add(1)
+				select {
+				case started <- struct{}{}:
+				default:
+				}
+				time.Sleep(30 * time.Millisecond)
+				running.Add(-1)
+			}
+			r.Schedule("guild", render)
+			<-started
+			r.Schedule("guild", render)
+			r.Schedule("guild", render)
+			time.Sleep(150 * time.Millisecond)
+			if renders.Load() != 2 {
+				t.Fatalf("Expected 2 renders, got %d", renders.Load())
+			}
+			if overlaps.Load() != 0 {
+				t.Fatalf("Expected renders not to overlap")
+			}
+		},
+	)
+}
+
+func Test_scheduleDebtsMessageUpdate(t *testing.T) {
+	renderer, render := debtsRenderer, renderDebtsMessage
+	t.Cleanup(
+		func() {
+			debtsRenderer, renderDebtsMessage = renderer, render
+		},
+	)
+	rendered := make(chan string, 10)
+	debtsRenderer = newRenderScheduler(10 * time.Millisecond)
+	renderDebtsMessage = func(_ context.Context, _ *state.State, _ domain.Service, guildId string) {
+		rendered <- guildId
+	}
+
+	scheduleDebtsMessageUpdate(nil, nil, "guild")
+	scheduleDebtsMessageUpdate(nil, nil, "guild")
+	select {
+	case guildId := <-rendered:
+		if guildId != "guild" {
+			t.Fatalf("Expected the debts message of guild to be rendered, got %s", guildId)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the debts message to be rendered")
+	}
+	select {
+	case guildId := <-rendered:
+		t.Fatalf("Expected a single render, got another one for %s", guildId)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,203 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/rs/zerolog/log"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/i18n"
+	"slash10k/pkg/models"
+	"slash10k/pkg/scheduler"
+	"strings"
+	"time"
+)
+
+// SummaryCatchUp is how late a weekly summary is still posted, e.g. when the bot was down at the time it was due.
+const SummaryCatchUp = time.Hour
+
+func Summary(service domain.Service) func(
+	ctx context.Context,
+	data cmdroute.CommandData,
+) *api.InteractionResponseData {
+	return func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+		guildId := data.Event.GuildID
+		log.Info().Msgf("summary called for guild %s", guildId)
+		locale := interactionLocale(ctx, service, data.Event)
+
+		var weeks int64
+		if week := data.Options.Find("week"); week.Name != "" {
+			var err error
+			weeks, err = week.IntValue()
+			if err != nil || weeks < 0 {
+				log.Error().Msgf("cannot get week: %s", err)
+				return ephemeralMessage(i18n.T(locale, i18n.SummaryFailed))
+			}
+		}
+
+		settings, err := service.GetGuildSettings(ctx, guildId.String())
+		if err != nil {
+			log.Error().Msgf("cannot get guild settings: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.SummaryFailed))
+		}
+		end := time.Now().Add(-time.Duration(weeks) * domain.SummaryPeriod)
+		summary, err := service.GetWeeklySummary(ctx, guildId.String(), end)
+		if err != nil {
+			log.Error().Msgf("cannot get weekly summary: %s", err)
+			return ephemeralMessage(i18n.T(locale, i18n.SummaryFailed))
+		}
+
+		return &api.InteractionResponseData{
+			Embeds: &[]discord.Embed{transformSummaryToEmbed(*summary, *settings, locale)},
+			Flags:  discord.EphemeralMessage,
+		}
+	}
+}
+
+// PostWeeklySummaries is a job that posts a summary of the past week in the setup channel, on the
+// weekday and at the time the guild chose.
+func PostWeeklySummaries(s *state.State, service domain.Service) scheduler.Job {
+	return func(ctx context.Context, now time.Time) {
+		guilds, err := service.GetGuildSettingsWithSummaries(ctx)
+		if err != nil {
+			log.Error().Msgf("cannot get guilds with summaries: %s", err)
+			return
+		}
+		for _, settings := range guilds {
+			postWeeklySummary(ctx, s, service, settings, now)
+		}
+	}
+}
+
+func postWeeklySummary(
+	ctx context.Context,
+	s *state.State,
+	service domain.Service,
+	settings models.GuildSettings,
+	now time.Time,
+) {
+	location, err := time.LoadLocation(settings.Timezone)
+	if err != nil {
+		log.Error().Msgf("cannot load timezone of guild %s: %s", settings.GuildId, err)
+		return
+	}
+	schedule := scheduler.Weekly{Weekday: settings.SummaryWeekday, Minute: settings.SummaryTime, Location: location}
+	dueAt, due := schedule.Due(now, SummaryCatchUp)
+	if !due {
+		return
+	}
+
+	botSetup, err := service.GetBotSetup(ctx, settings.GuildId)
+	if errors.Is(err, domain.ErrBotSetupDoesNotExist) {
+		return
+	} else if err != nil {
+		log.Error().Msgf("cannot get bot setup of guild %s: %s", settings.GuildId, err)
+		return
+	}
+
+	claimed, err := service.ClaimWeeklySummary(ctx, settings.GuildId, dueAt)
+	if err != nil {
+		log.Error().Msgf("cannot claim weekly summary of guild %s: %s", settings.GuildId, err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	summary, err := service.GetWeeklySummary(ctx, settings.GuildId, dueAt)
+	if err != nil {
+		log.Error().Msgf("cannot get weekly summary of guild %s: %s", settings.GuildId, err)
+		return
+	}
+	channelId, _ := botSetupToDiscordTypes(*botSetup)
+	_, err = s.SendMessageComplex(
+		channelId, api.SendMessageData{
+			Embeds: []discord.Embed{transformSummaryToEmbed(*summary, settings, guildLocale(s, settings))},
+		},
+	)
+	if err != nil {
+		log.Error().Msgf("cannot post weekly summary of guild %s: %s", settings.GuildId, err)
+		return
+	}
+	log.Info().Msgf("posted weekly summary of guild %s", settings.GuildId)
+}
+
+func transformSummaryToEmbed(
+	summary models.WeeklySummary,
+	settings models.GuildSettings,
+	locale i18n.Locale,
+) discord.Embed {
+	debtors := make([]string, 0, len(summary.BiggestDebtors))
+	for i, player := range summary.BiggestDebtors {
+		debtors = append(debtors, fmt.Sprintf("%d. %s – %s", i+1, player.Name, formatAmount(player.Debt.Amount)))
+	}
+	debtFree := make([]string, 0, len(summary.DebtFree))
+	for _, player := range summary.DebtFree {
+		debtFree = append(debtFree, player.Name)
+	}
+
+	return discord.Embed{
+		Title:       i18n.T(locale, i18n.SummaryTitle),
+		Type:        discord.NormalEmbed,
+		Description: fmt.Sprintf("<t:%d:d> – <t:%d:d>", summary.Start, summary.End),
+		Color:       discord.Color(settings.BoardColor),
+		Fields: []discord.EmbedField{
+			{
+				Name: i18n.T(locale, i18n.SummaryPenalties),
+				Value: i18n.T(
+					locale,
+					i18n.SummaryPenaltiesValue,
+					summary.PenaltyCount,
+					formatAmount(summary.Penalties),
+				),
+				Inline: true,
+			},
+			{
+				Name: i18n.T(locale, i18n.SummaryPayments),
+				Value: i18n.T(
+					locale,
+					i18n.SummaryPaymentsValue,
+					summary.PaymentCount,
+					formatAmount(summary.Payments),
+				),
+				Inline: true,
+			},
+			{
+				Name: i18n.T(locale, i18n.SummaryNetChange),
+				Value: i18n.T(
+					locale,
+					i18n.SummaryNetChangeValue,
+					formatSignedAmount(summary.NetChange),
+					formatSignedAmount(summary.PreviousNetChange),
+				),
+				Inline: true,
+			},
+			{
+				Name:  i18n.T(locale, i18n.SummaryBiggestDebtors),
+				Value: summaryList(debtors, "\n", locale),
+			},
+			{
+				Name:  i18n.T(locale, i18n.SummaryDebtFree),
+				Value: summaryList(debtFree, ", ", locale),
+			},
+		},
+	}
+}
+
+func summaryList(items []string, separator string, locale i18n.Locale) string {
+	if len(items) == 0 {
+		return i18n.T(locale, i18n.SummaryNobody)
+	}
+	return truncate(strings.Join(items, separator), MaxEmbedFieldLength)
+}
+
+func formatSignedAmount(amount int64) string {
+	if amount > 0 {
+		return "+" + formatAmount(amount)
+	}
+	return formatAmount(amount)
+}
@@ -0,0 +1,156 @@
+package command
+
+import (
+	"fmt"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"slash10k/pkg/i18n"
+	"slash10k/pkg/models"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func players(n int) models.Players {
+	res := make(models.Players, n)
+	for i := range res {
+		res[i] = models.Player{
+			DiscordId:   fmt.Sprintf("%d", i),
+			DiscordName: fmt.Sprintf("player-with-a-long-name-%03d", i),
+			Name:        fmt.Sprintf("Player with a long name %03d", i),
+			Debt:        models.Debt{Amount: 1234567},
+		}
+	}
+	return res
+}
+
+func Test_transformDebtsToEmbed(t *testing.T) {
+	for _, locale := range i18n.Locales {
+		for _, n := range []int{1, 40, 300} {
+			t.Run(
+				fmt.Sprintf("%d players in %s", n, locale), func(t *testing.T) {
+					embed := transformDebtsToEmbed(players(n), models.DefaultGuildSettings("guild"), locale)
+					if len(embed.Fields) > MaxEmbedFields {
+						t.Fatalf("Expected at most %d fields, got %d", MaxEmbedFields, len(embed.Fields))
+					}
+					total := 0
+					for _, f := range embed.Fields {
+						if len(f.Value) > MaxEmbedFieldLength {
+							t.Fatalf("Expected fields of at most %d characters, got %d", MaxEmbedFieldLength, len(f.Value))
+						}
+						total += len(f.Name) + len(f.Value)
+					}
+					if total > MaxEmbedFieldsLength {
+						t.Fatalf("Expected fields of at most %d characters in total, got %d", MaxEmbedFieldsLength, total)
+					}
+					if n <= 40 && embed.Footer != nil {
+						t.Fatalf("Expected all %d players to be shown, got footer %q", n, embed.Footer.Text)
+					}
+					if n > 40 && embed.Footer == nil {
+						t.Fatalf("Expected a footer for the players that are not shown")
+					}
+					shown := 0
+					for _, f := range embed.Fields {
+						shown += strings.Count(f.Value, "\n")
+					}
+					if hidden := strconv.Itoa(n - shown); n > shown && !strings.Contains(embed.Footer.Text, hidden) {
+						t.Fatalf("Expected the footer to name the %s players that are not shown, got %q", hidden, embed.Footer.Text)
+					}
+					if embed.Title != strings.ReplaceAll(i18n.T(locale, i18n.BoardTitle), models.BoardTitleAmount, "10k") {
+						t.Fatalf("Expected the default title in %s, got %q", locale, embed.Title)
+					}
+				},
+			)
+		}
+	}
+}
+
+func Test_transformDebtsToEmbed_pendingPayment(t *testing.T) {
+	p := players(2)
+	p[1].PendingPayment = 5000
+	embed := transformDebtsToEmbed(p, models.DefaultGuildSettings("guild"), i18n.DefaultLocale)
+	lines := strings.Split(strings.Trim(embed.Fields[0].Value, "`\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %q", embed.Fields[0].Value)
+	}
+	if strings.Contains(lines[0], PendingPaymentMarker) {
+		t.Errorf("Expected no marker without pending payments, got %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], PendingPaymentMarker+" -5000") {
+		t.Errorf("Expected the pending payment after the debt, got %q", lines[1])
+	}
+}
+
+func Test_transformSummaryToEmbed(t *testing.T) {
+	summary := models.WeeklySummary{
+		Penalties:         30000,
+		PenaltyCount:      2,
+		Payments:          10000,
+		PaymentCount:      1,
+		NetChange:         20000,
+		PreviousNetChange: -5000,
+		BiggestDebtors:    players(2),
+	}
+	for _, locale := range i18n.Locales {
+		embed := transformSummaryToEmbed(summary, models.DefaultGuildSettings("guild"), locale)
+		if len(embed.Fields) != 5 {
+			t.Fatalf("Expected 5 fields, got %d", len(embed.Fields))
+		}
+		if !strings.Contains(embed.Fields[2].Value, "+20k") || !strings.Contains(embed.Fields[2].Value, "-5k") {
+			t.Errorf("Expected signed net changes, got %q", embed.Fields[2].Value)
+		}
+		if len(strings.Split(embed.Fields[3].Value, "\n")) != 2 {
+			t.Errorf("Expected a line per debtor, got %q", embed.Fields[3].Value)
+		}
+		if embed.Fields[4].Value != i18n.T(locale, i18n.SummaryNobody) {
+			t.Errorf("Expected nobody to be debt-free, got %q", embed.Fields[4].Value)
+		}
+	}
+}
+
+func Test_debtsMessageButtonComponents(t *testing.T) {
+	for _, n := range []int{0, 25, 40, 100, 101} {
+		t.Run(
+			fmt.Sprintf("%d players", n), func(t *testing.T) {
+				components := debtsMessageButtonComponents(players(n), i18n.DefaultLocale)
+				if len(components) > 5 {
+					t.Fatalf("Expected at most 5 action rows, got %d", len(components))
+				}
+				selectable := 0
+				for _, row := range components {
+					for _, c := range *row.(*discord.ActionRowComponent) {
+						if s, ok := c.(*discord.StringSelectComponent); ok {
+							if len(s.Options) > MaxSelectOptions {
+								t.Fatalf("Expected at most %d options, got %d", MaxSelectOptions, len(s.Options))
+							}
+							selectable += len(s.Options)
+						}
+					}
+				}
+				if n <= MaxPlayerSelects*MaxSelectOptions && selectable != n {
+					t.Fatalf("Expected %d selectable players, got %d", n, selectable)
+				}
+			},
+		)
+	}
+}
+
+func Test_playerPage(t *testing.T) {
+	all := players(101)
+	selectable := 0
+	for page := 0; page < 5; page++ {
+		data := playerPage(all, page, i18n.DefaultLocale)
+		row := *(*data.Components)[0].(*discord.ActionRowComponent)
+		selectable += len(row[0].(*discord.StringSelectComponent).Options)
+	}
+	if selectable != 101 {
+		t.Fatalf("Expected all 101 players on the pages, got %d", selectable)
+	}
+}
+
+func Test_playerIdFromOption(t *testing.T) {
+	for _, value := range []string{"263352209654153236", "<@263352209654153236>", "<@!263352209654153236>", " 263352209654153236 "} {
+		if got := playerIdFromOption(value); got != "263352209654153236" {
+			t.Errorf("playerIdFromOption(%q) = %q, want 263352209654153236", value, got)
+		}
+	}
+}
@@ -1,9 +1,14 @@
 package command
 
 import (
+	"errors"
+	"fmt"
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"math"
+	"strconv"
+	"strings"
 )
 
 func ephemeralMessage(content string) *api.InteractionResponseData {
@@ -12,3 +17,71 @@ func ephemeralMessage(content string) *api.InteractionResponseData {
 		Flags:   discord.EphemeralMessage,
 	}
 }
+
+func senderName(event *discord.InteractionEvent) string {
+	if event.Member != nil && event.Member.Nick != "" {
+		return event.Member.Nick
+	}
+	sender := event.Sender()
+	if sender == nil {
+		return ""
+	}
+	return memberName("", *sender)
+}
+
+// memberName is the name a member is shown with in the guild: the guild nickname,
+// otherwise the global display name, otherwise the username.
+func memberName(nick string, user discord.User) string {
+	if nick != "" {
+		return nick
+	}
+	if user.DisplayName != "" {
+		return user.DisplayName
+	}
+	return user.Username
+}
+
+// parseAmount parses amounts as typed by players, e.g. "25000", "10k" or "1.5k".
+// A comma is accepted as decimal separator as well.
+func parseAmount(value string) (int64, error) {
+	v := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(value), " ", ""))
+	v = strings.ReplaceAll(v, ",", ".")
+	multiplier := 1.0
+	if strings.HasSuffix(v, "k") {
+		multiplier = 1000
+		v = strings.TrimSuffix(v, "k")
+	}
+	if v == "" {
+		return 0, errors.New("empty amount")
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	amount := f * multiplier
+	if amount != math.Trunc(amount) || math.Abs(amount) > math.MaxInt64/2 {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	return int64(amount), nil
+}
+
+// formatAmount formats amounts the way players talk about them, e.g. "10k" or "2.5k".
+func formatAmount(amount int64) string {
+	switch {
+	case amount != 0 && amount%1000 == 0:
+		return fmt.Sprintf("%dk", amount/1000)
+	case amount != 0 && amount%100 == 0:
+		return strconv.FormatFloat(float64(amount)/1000, 'f', 1, 64) + "k"
+	default:
+		return strconv.FormatInt(amount, 10)
+	}
+}
+
+// truncate shortens s to at most maxLength characters, marking the cut with an ellipsis.
+func truncate(s string, maxLength int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLength {
+		return s
+	}
+	return string(runes[:maxLength-1]) + "…"
+}
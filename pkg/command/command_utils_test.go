@@ -0,0 +1,95 @@
+package command
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"testing"
+)
+
+func Test_parseAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{name: "plain number", value: "25000", want: 25000},
+		{name: "thousands suffix", value: "10k", want: 10000},
+		{name: "upper case suffix", value: "10K", want: 10000},
+		{name: "decimal with suffix", value: "1.5k", want: 1500},
+		{name: "decimal comma with suffix", value: "2,5k", want: 2500},
+		{name: "surrounding spaces", value: " 5 k ", want: 5000},
+		{name: "negative", value: "-10k", want: -10000},
+		{name: "fraction of gold", value: "1.0005k", wantErr: true},
+		{name: "empty", value: "", wantErr: true},
+		{name: "only suffix", value: "k", wantErr: true},
+		{name: "text", value: "ten", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				got, err := parseAmount(tt.value)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("parseAmount() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if got != tt.want {
+					t.Errorf("parseAmount() = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
+
+func Test_formatAmount(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		want   string
+	}{
+		{name: "zero", amount: 0, want: "0"},
+		{name: "whole thousands", amount: 10000, want: "10k"},
+		{name: "half thousands", amount: 2500, want: "2.5k"},
+		{name: "negative thousands", amount: -5000, want: "-5k"},
+		{name: "odd amount", amount: 1234, want: "1234"},
+		{name: "below thousand", amount: 500, want: "0.5k"},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				if got := formatAmount(tt.amount); got != tt.want {
+					t.Errorf("formatAmount() = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
+
+func Test_memberName(t *testing.T) {
+	tests := []struct {
+		name string
+		nick string
+		user discord.User
+		want string
+	}{
+		{
+			name: "nickname wins",
+			nick: "torf",
+			user: discord.User{Username: "torfstack", DisplayName: "Torfstack"},
+			want: "torf",
+		},
+		{
+			name: "display name without nickname",
+			user: discord.User{Username: "torfstack", DisplayName: "Torfstack"},
+			want: "Torfstack",
+		},
+		{name: "username without display name", user: discord.User{Username: "torfstack"}, want: "torfstack"},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				if got := memberName(tt.nick, tt.user); got != tt.want {
+					t.Errorf("memberName() = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
@@ -2,8 +2,11 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 const (
@@ -12,6 +15,12 @@ const (
 	DefaultUser     = "postgres"
 	DefaultPassword = "postgres"
 	DefaultDatabase = "slash10kdev"
+
+	DefaultPoolMaxConns        = 10
+	DefaultPoolMinConns        = 1
+	DefaultPoolMaxConnIdleTime = 30 * time.Minute
+
+	DefaultGuildRetentionPeriod = 30 * 24 * time.Hour
 )
 
 type Config struct {
@@ -20,6 +29,20 @@ type Config struct {
 	User     string
 	Password string
 	Database string
+
+	PoolMaxConns        int32
+	PoolMinConns        int32
+	PoolMaxConnIdleTime time.Duration
+
+	// BotOwnerIds are the discord user ids that may administer the bot in every guild.
+	BotOwnerIds []string
+
+	// GuildRetentionPeriod is how long the data of a guild is kept after the bot was removed from it.
+	// It must be positive, a guild that removes the bot only briefly would lose its data otherwise.
+	GuildRetentionPeriod time.Duration
+
+	// ApiAddress is the address the read-only http api listens on, e.g. ":8080". The api is not served if empty.
+	ApiAddress string
 }
 
 type Option func(*Config)
@@ -31,6 +54,12 @@ func NewConfig(os ...Option) Config {
 		User:     DefaultUser,
 		Password: DefaultPassword,
 		Database: DefaultDatabase,
+
+		PoolMaxConns:        DefaultPoolMaxConns,
+		PoolMinConns:        DefaultPoolMinConns,
+		PoolMaxConnIdleTime: DefaultPoolMaxConnIdleTime,
+
+		GuildRetentionPeriod: DefaultGuildRetentionPeriod,
 	}
 	for _, o := range os {
 		o(c)
@@ -50,13 +79,61 @@ func NewConfigFromEnv() (Config, error) {
 		return Config{}, errors.New("missing or malformed environment variables for database connection")
 	}
 
-	return NewConfig(
+	opts := []Option{
 		WithHostName(host),
 		WithPort(port),
 		WithUser(user),
 		WithPassword(password),
 		WithDatabase(database),
-	), nil
+		WithBotOwnerIds(splitList(os.Getenv("BOT_OWNER_IDS"))...),
+		WithApiAddress(os.Getenv("API_ADDRESS")),
+	}
+
+	if maxConnsS := os.Getenv("DATABASE_POOL_MAX_CONNS"); maxConnsS != "" {
+		maxConns, err := strconv.ParseInt(maxConnsS, 10, 32)
+		if err != nil || maxConns < 1 {
+			return Config{}, fmt.Errorf("malformed environment variable DATABASE_POOL_MAX_CONNS: %s", maxConnsS)
+		}
+		opts = append(opts, WithPoolMaxConns(int32(maxConns)))
+	}
+	if minConnsS := os.Getenv("DATABASE_POOL_MIN_CONNS"); minConnsS != "" {
+		minConns, err := strconv.ParseInt(minConnsS, 10, 32)
+		if err != nil || minConns < 0 {
+			return Config{}, fmt.Errorf("malformed environment variable DATABASE_POOL_MIN_CONNS: %s", minConnsS)
+		}
+		opts = append(opts, WithPoolMinConns(int32(minConns)))
+	}
+	if idleTimeS := os.Getenv("DATABASE_POOL_MAX_CONN_IDLE_TIME"); idleTimeS != "" {
+		idleTime, err := time.ParseDuration(idleTimeS)
+		if err != nil || idleTime <= 0 {
+			return Config{}, fmt.Errorf("malformed environment variable DATABASE_POOL_MAX_CONN_IDLE_TIME: %s", idleTimeS)
+		}
+		opts = append(opts, WithPoolMaxConnIdleTime(idleTime))
+	}
+
+	if retentionS := os.Getenv("GUILD_RETENTION_PERIOD"); retentionS != "" {
+		retention, err := time.ParseDuration(retentionS)
+		if err != nil || retention <= 0 {
+			return Config{}, fmt.Errorf("malformed environment variable GUILD_RETENTION_PERIOD: %s", retentionS)
+		}
+		opts = append(opts, WithGuildRetentionPeriod(retention))
+	}
+
+	c := NewConfig(opts...)
+	if c.PoolMinConns > c.PoolMaxConns {
+		return Config{}, errors.New("DATABASE_POOL_MIN_CONNS must not exceed DATABASE_POOL_MAX_CONNS")
+	}
+	return c, nil
+}
+
+func splitList(list string) []string {
+	res := make([]string, 0)
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
 }
 
 func (c Config) ConnectionString() string {
@@ -92,3 +169,39 @@ func WithDatabase(database string) Option {
 		c.Database = database
 	}
 }
+
+func WithBotOwnerIds(ids ...string) Option {
+	return func(c *Config) {
+		c.BotOwnerIds = ids
+	}
+}
+
+func WithPoolMaxConns(maxConns int32) Option {
+	return func(c *Config) {
+		c.PoolMaxConns = maxConns
+	}
+}
+
+func WithPoolMinConns(minConns int32) Option {
+	return func(c *Config) {
+		c.PoolMinConns = minConns
+	}
+}
+
+func WithPoolMaxConnIdleTime(maxConnIdleTime time.Duration) Option {
+	return func(c *Config) {
+		c.PoolMaxConnIdleTime = maxConnIdleTime
+	}
+}
+
+func WithGuildRetentionPeriod(retention time.Duration) Option {
+	return func(c *Config) {
+		c.GuildRetentionPeriod = retention
+	}
+}
+
+func WithApiAddress(address string) Option {
+	return func(c *Config) {
+		c.ApiAddress = address
+	}
+}
@@ -3,6 +3,7 @@ package fromdb
 import (
 	"slash10k/pkg/models"
 	sqlc "slash10k/sql/gen"
+	"time"
 )
 
 func FromPlayerWithoutDebt(player sqlc.Player) models.Player {
@@ -12,6 +13,7 @@ func FromPlayerWithoutDebt(player sqlc.Player) models.Player {
 		DiscordName: player.DiscordName,
 		GuildId:     player.GuildID,
 		Name:        player.Name,
+		Active:      player.Active,
 	}
 }
 
@@ -21,11 +23,27 @@ func FromPlayerWithDebt(playerWithDebt sqlc.GetPlayerRow) models.Player {
 	return player
 }
 
+func FromLockedPlayerWithDebt(playerWithDebt sqlc.GetPlayerForUpdateRow) models.Player {
+	return FromPlayerWithDebt(sqlc.GetPlayerRow(playerWithDebt))
+}
+
 func FromAllPlayers(allPlayers []sqlc.GetAllPlayersRow) []models.Player {
 	players := make([]models.Player, len(allPlayers))
 	for i, player := range allPlayers {
 		p := FromPlayerWithoutDebt(player.Player)
 		p.Debt = FromDebt(player.Debt)
+		p.PendingPayment = player.PendingAmount
+		players[i] = p
+	}
+	return players
+}
+
+func FromAllPlayersWithInactive(allPlayers []sqlc.GetAllPlayersWithInactiveRow) []models.Player {
+	players := make([]models.Player, len(allPlayers))
+	for i, player := range allPlayers {
+		p := FromPlayerWithoutDebt(player.Player)
+		p.Debt = FromDebt(player.Debt)
+		p.PendingPayment = player.PendingAmount
 		players[i] = p
 	}
 	return players
@@ -47,16 +65,60 @@ func FromDebtJournal(debtJournal sqlc.DebtJournal) models.DebtJournalEntry {
 		Description: debtJournal.Description,
 		Date:        debtJournal.Date.Time.Unix(),
 		UserId:      debtJournal.UserID,
+		Category:    debtJournal.Category,
+	}
+}
+
+func FromJournalEntriesOfGuild(guildId string, entries []sqlc.GetJournalEntriesOfGuildRow) []models.DebtJournalEntry {
+	res := make([]models.DebtJournalEntry, len(entries))
+	for i, entry := range entries {
+		e := FromDebtJournal(entry.DebtJournal)
+		e.GuildId = guildId
+		e.DiscordId = entry.DiscordID
+		e.PlayerName = entry.Name
+		res[i] = e
+	}
+	return res
+}
+
+func FromJournalEntriesOfGuildBetween(
+	guildId string,
+	entries []sqlc.GetJournalEntriesOfGuildBetweenRow,
+) []models.DebtJournalEntry {
+	res := make([]models.DebtJournalEntry, len(entries))
+	for i, entry := range entries {
+		e := FromDebtJournal(entry.DebtJournal)
+		e.GuildId = guildId
+		e.DiscordId = entry.DiscordID
+		e.PlayerName = entry.Name
+		res[i] = e
 	}
+	return res
+}
+
+func FromJournalEntriesOfPlayer(guildId string, entries []sqlc.GetJournalEntriesOfPlayerRow) []models.DebtJournalEntry {
+	res := make([]models.DebtJournalEntry, len(entries))
+	for i, entry := range entries {
+		e := FromDebtJournal(entry.DebtJournal)
+		e.GuildId = guildId
+		e.DiscordId = entry.DiscordID
+		e.PlayerName = entry.Name
+		res[i] = e
+	}
+	return res
 }
 
 func FromBotSetup(botSetup sqlc.BotSetup) models.BotSetup {
-	return models.BotSetup{
+	res := models.BotSetup{
 		GuildId:               botSetup.GuildID,
 		ChannelId:             botSetup.ChannelID,
 		RegistrationMessageId: botSetup.RegistrationMessageID,
 		DebtsMessageId:        botSetup.DebtsMessageID,
 	}
+	if botSetup.AwaitingReactionsUntil.Valid {
+		res.AwaitingReactionsUntil = botSetup.AwaitingReactionsUntil.Time.Unix()
+	}
+	return res
 }
 
 func FromBotSetups(botSetups []sqlc.BotSetup) []models.BotSetup {
@@ -66,3 +128,51 @@ func FromBotSetups(botSetups []sqlc.BotSetup) []models.BotSetup {
 	}
 	return botSetupsConverted
 }
+
+func FromGuildSettings(guildSettings sqlc.GuildSetting) models.GuildSettings {
+	return models.GuildSettings{
+		GuildId:            guildSettings.GuildID,
+		PenaltyAmount:      guildSettings.PenaltyAmount,
+		AdminRoleId:        guildSettings.AdminRoleID,
+		RegistrationEmoji:  guildSettings.RegistrationEmoji,
+		BoardTitle:         guildSettings.BoardTitle,
+		BoardColor:         guildSettings.BoardColor,
+		SortOrder:          models.SortOrder(guildSettings.SortOrder),
+		Locale:             guildSettings.Locale,
+		TreasurerChannelId: guildSettings.TreasurerChannelID,
+		ReminderWeekday:    time.Weekday(guildSettings.ReminderWeekday),
+		ReminderTime:       guildSettings.ReminderTime,
+		ReminderThreshold:  guildSettings.ReminderThreshold,
+		Timezone:           guildSettings.Timezone,
+		SummaryWeekday:     time.Weekday(guildSettings.SummaryWeekday),
+		SummaryTime:        guildSettings.SummaryTime,
+	}
+}
+
+func FromPendingPayment(payment sqlc.GetPendingPaymentForUpdateRow) models.PendingPayment {
+	return models.PendingPayment{
+		Id:         payment.PendingPayment.ID,
+		GuildId:    payment.Player.GuildID,
+		DiscordId:  payment.Player.DiscordID,
+		PlayerName: payment.Player.Name,
+		Amount:     payment.PendingPayment.Amount,
+		CreatedAt:  payment.PendingPayment.CreatedAt.Time.Unix(),
+	}
+}
+
+func FromPenaltyCategory(category sqlc.PenaltyCategory) models.PenaltyCategory {
+	return models.PenaltyCategory{
+		Id:      category.ID,
+		GuildId: category.GuildID,
+		Name:    category.Name,
+		Amount:  category.Amount,
+	}
+}
+
+func FromPenaltyCategories(categories []sqlc.PenaltyCategory) []models.PenaltyCategory {
+	categoriesConverted := make([]models.PenaltyCategory, len(categories))
+	for i, category := range categories {
+		categoriesConverted[i] = FromPenaltyCategory(category)
+	}
+	return categoriesConverted
+}
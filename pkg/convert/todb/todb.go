@@ -14,6 +14,7 @@ func ToPlayer(player models.Player) sqlc.Player {
 		DiscordName: player.DiscordName,
 		GuildID:     player.GuildId,
 		Name:        player.Name,
+		Active:      player.Active,
 	}
 }
 
@@ -38,6 +39,7 @@ func ToDebtJournal(debtJournal models.DebtJournalEntry) sqlc.DebtJournal {
 			Time:  time.Unix(debtJournal.Date, 0),
 			Valid: true,
 		},
-		UserID: debtJournal.UserId,
+		UserID:   debtJournal.UserId,
+		Category: debtJournal.Category,
 	}
 }
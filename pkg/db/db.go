@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 	sqlc "slash10k/sql/gen"
+	"time"
 )
 
 //go:generate mockgen -destination=../mocks/db_mocks.go -package=mock_db slash10k/pkg/db Database,Connection,Queries,Transaction
@@ -28,66 +31,168 @@ type Queries interface {
 	NumberOfPlayers(ctx context.Context) (int64, error)
 	AddPlayer(ctx context.Context, param sqlc.AddPlayerParams) (sqlc.Player, error)
 	DeletePlayer(ctx context.Context, id int32) error
+	SetPlayerActive(ctx context.Context, params sqlc.SetPlayerActiveParams) error
+	UpdatePlayerName(ctx context.Context, params sqlc.UpdatePlayerNameParams) (int64, error)
 	GetIdOfPlayer(ctx context.Context, param sqlc.GetIdOfPlayerParams) (int32, error)
 	GetPlayer(ctx context.Context, params sqlc.GetPlayerParams) (sqlc.GetPlayerRow, error)
+	GetPlayerForUpdate(ctx context.Context, params sqlc.GetPlayerForUpdateParams) (sqlc.GetPlayerForUpdateRow, error)
 	GetAllPlayers(ctx context.Context, guildId string) ([]sqlc.GetAllPlayersRow, error)
+	GetAllPlayersWithInactive(ctx context.Context, guildId string) ([]sqlc.GetAllPlayersWithInactiveRow, error)
+	DeletePlayersOfGuild(ctx context.Context, guildId string) error
 	DoesPlayerExist(ctx context.Context, params sqlc.DoesPlayerExistParams) (bool, error)
 
 	SetDebt(ctx context.Context, params sqlc.SetDebtParams) error
+	AddToDebt(ctx context.Context, params sqlc.AddToDebtParams) (int64, error)
 
 	AddJournalEntry(ctx context.Context, params sqlc.AddJournalEntryParams) (sqlc.DebtJournal, error)
 	GetJournalEntries(ctx context.Context, params int32) ([]sqlc.DebtJournal, error)
+	GetJournalEntriesOfGuild(
+		ctx context.Context,
+		params sqlc.GetJournalEntriesOfGuildParams,
+	) ([]sqlc.GetJournalEntriesOfGuildRow, error)
+	GetJournalEntriesOfPlayer(
+		ctx context.Context,
+		params sqlc.GetJournalEntriesOfPlayerParams,
+	) ([]sqlc.GetJournalEntriesOfPlayerRow, error)
+	GetJournalEntriesOfGuildBetween(
+		ctx context.Context,
+		params sqlc.GetJournalEntriesOfGuildBetweenParams,
+	) ([]sqlc.GetJournalEntriesOfGuildBetweenRow, error)
 	UpdateJournalEntry(ctx context.Context, params sqlc.UpdateJournalEntryParams) (sqlc.DebtJournal, error)
 	DeleteJournalEntry(ctx context.Context, id int32) error
 
 	GetBotSetup(ctx context.Context, guildId string) (sqlc.BotSetup, error)
 	DoesBotSetupExist(ctx context.Context, guildId string) (bool, error)
 	PutBotSetup(ctx context.Context, params sqlc.PutBotSetupParams) (sqlc.BotSetup, error)
+	UpdateBotSetupMessages(ctx context.Context, params sqlc.UpdateBotSetupMessagesParams) error
+	SetBotSetupAwaitingReactions(ctx context.Context, params sqlc.SetBotSetupAwaitingReactionsParams) error
 	DeleteBotSetup(ctx context.Context, guildId string) error
 	GetAllBotSetups(ctx context.Context) ([]sqlc.BotSetup, error)
+
+	GetGuildSettings(ctx context.Context, guildId string) (sqlc.GuildSetting, error)
+	DoGuildSettingsExist(ctx context.Context, guildId string) (bool, error)
+	PutGuildSettings(ctx context.Context, params sqlc.PutGuildSettingsParams) (sqlc.GuildSetting, error)
+	DeleteGuildSettings(ctx context.Context, guildId string) error
+	GetGuildSettingsWithReminders(ctx context.Context) ([]sqlc.GuildSetting, error)
+	GetGuildSettingsWithSummaries(ctx context.Context) ([]sqlc.GuildSetting, error)
+
+	GetPenaltyCategories(ctx context.Context, guildId string) ([]sqlc.PenaltyCategory, error)
+	GetPenaltyCategory(ctx context.Context, params sqlc.GetPenaltyCategoryParams) (sqlc.PenaltyCategory, error)
+	DoesPenaltyCategoryExist(ctx context.Context, params sqlc.DoesPenaltyCategoryExistParams) (bool, error)
+	NumberOfPenaltyCategories(ctx context.Context, guildId string) (int64, error)
+	AddPenaltyCategory(ctx context.Context, params sqlc.AddPenaltyCategoryParams) (sqlc.PenaltyCategory, error)
+	DeletePenaltyCategory(ctx context.Context, params sqlc.DeletePenaltyCategoryParams) error
+	DeletePenaltyCategoriesOfGuild(ctx context.Context, guildId string) error
+
+	AddPendingPayment(ctx context.Context, params sqlc.AddPendingPaymentParams) (sqlc.PendingPayment, error)
+	GetPendingPaymentSum(ctx context.Context, userId int32) (int64, error)
+	GetPendingPaymentForUpdate(
+		ctx context.Context,
+		params sqlc.GetPendingPaymentForUpdateParams,
+	) (sqlc.GetPendingPaymentForUpdateRow, error)
+	DeletePendingPayment(ctx context.Context, id int32) error
+
+	GetPlayersToRemind(ctx context.Context, params sqlc.GetPlayersToRemindParams) ([]sqlc.GetPlayersToRemindRow, error)
+	AddReminder(ctx context.Context, params sqlc.AddReminderParams) (int64, error)
+	DeleteRemindersBefore(ctx context.Context, params sqlc.DeleteRemindersBeforeParams) error
+	AddReminderOptOut(ctx context.Context, userId int32) error
+	DeleteReminderOptOut(ctx context.Context, userId int32) error
+
+	AddWeeklySummary(ctx context.Context, params sqlc.AddWeeklySummaryParams) (int64, error)
+	DeleteWeeklySummariesBefore(ctx context.Context, params sqlc.DeleteWeeklySummariesBeforeParams) error
+	DeleteWeeklySummariesOfGuild(ctx context.Context, guildId string) error
+
+	PutApiToken(ctx context.Context, params sqlc.PutApiTokenParams) error
+	GetGuildOfApiToken(ctx context.Context, tokenHash string) (string, error)
+	DeleteApiToken(ctx context.Context, guildId string) (int64, error)
+
+	MarkGuildOrphaned(ctx context.Context, guildId string) error
+	UnmarkGuildOrphaned(ctx context.Context, guildId string) error
+	GetOrphanedGuilds(ctx context.Context, orphanedAt pgtype.Timestamp) ([]string, error)
 }
 
-type database struct {
-	connectionString string
+type PoolOpts func(*pgxpool.Config)
+
+func WithMaxConns(maxConns int32) PoolOpts {
+	return func(config *pgxpool.Config) {
+		config.MaxConns = maxConns
+	}
 }
 
-func NewDatabase(connectionString string) Database {
-	return &database{connectionString: connectionString}
+func WithMinConns(minConns int32) PoolOpts {
+	return func(config *pgxpool.Config) {
+		config.MinConns = minConns
+	}
 }
 
-func (d database) Connect(ctx context.Context) (Connection, error) {
-	conn, err := GetConnection(ctx, d.connectionString)
+func WithMaxConnIdleTime(maxConnIdleTime time.Duration) PoolOpts {
+	return func(config *pgxpool.Config) {
+		config.MaxConnIdleTime = maxConnIdleTime
+	}
+}
+
+// PooledDatabase is the Database of a pool of connections, whoever creates it closes it.
+type PooledDatabase struct {
+	pool *pgxpool.Pool
+}
+
+// NewDatabase creates a pool of connections to the database. Connections are established lazily,
+// options that are not given fall back to the defaults of pgxpool.
+func NewDatabase(ctx context.Context, connectionString string, opts ...PoolOpts) (*PooledDatabase, error) {
+	config, err := pgxpool.ParseConfig(connectionString)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse connection string: %w", err)
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("could not create connection pool: %w", err)
+	}
+	return &PooledDatabase{pool: pool}, nil
+}
+
+func (d *PooledDatabase) Connect(ctx context.Context) (Connection, error) {
+	conn, err := d.pool.Acquire(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not establish db connection: %w", err)
 	}
-	return connection{conn, make([]transaction, 0)}, nil
+	return &connection{conn: conn}, nil
+}
+
+// Close closes all connections of the pool, it waits for acquired connections to be released.
+func (d *PooledDatabase) Close() {
+	d.pool.Close()
 }
 
 type connection struct {
-	conn *pgx.Conn
-	txs  []transaction
+	conn *pgxpool.Conn
+	txs  []*transaction
 }
 
-func (c connection) Close(ctx context.Context) {
+// Close rolls back all transactions that have not been committed and returns the connection to the pool.
+func (c *connection) Close(ctx context.Context) {
 	for _, tx := range c.txs {
 		if !tx.didCommit {
 			_ = tx.tx.Rollback(ctx)
 		}
 	}
-	_ = c.conn.Close(ctx)
+	c.conn.Release()
 }
 
-func (c connection) StartTransaction(ctx context.Context) (Transaction, error) {
+func (c *connection) StartTransaction(ctx context.Context) (Transaction, error) {
 	tx, err := c.conn.Begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not start transaction: %w", err)
 	}
-	ts := transaction{tx: tx}
+	ts := &transaction{tx: tx}
 	c.txs = append(c.txs, ts)
 	return ts, nil
 }
 
-func (c connection) Queries() Queries {
+func (c *connection) Queries() Queries {
 	return sqlc.New(c.conn)
 }
 
@@ -96,10 +201,15 @@ type transaction struct {
 	didCommit bool
 }
 
-func (t transaction) Commit(ctx context.Context) error {
-	return t.tx.Commit(ctx)
+func (t *transaction) Commit(ctx context.Context) error {
+	err := t.tx.Commit(ctx)
+	if err != nil {
+		return err
+	}
+	t.didCommit = true
+	return nil
 }
 
-func (t transaction) Queries() Queries {
+func (t *transaction) Queries() Queries {
 	return sqlc.New(t.tx)
 }
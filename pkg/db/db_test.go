@@ -3,13 +3,16 @@ package db_test
 import (
 	"context"
 	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
 	"slash10k/pkg/db"
+	"slash10k/pkg/domain"
 	"slash10k/pkg/testutil"
 	sqlc "slash10k/sql/gen"
 	"slices"
+	"sync"
 	"testing"
 	"time"
 )
@@ -61,6 +64,33 @@ func Test_Connection(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "deactivated players keep their debt but are not part of all players",
+			withConnection: func(t *testing.T, conn db.Connection, ctx context.Context) {
+				p1, _ := conn.Queries().AddPlayer(ctx, testutil.AddPlayerParams("torfstack"))
+				p2, _ := conn.Queries().AddPlayer(ctx, testutil.AddPlayerParams("neruh"))
+				_ = conn.Queries().SetDebt(ctx, sqlc.SetDebtParams{Amount: 80000, UserID: p2.ID})
+				err := conn.Queries().SetPlayerActive(ctx, sqlc.SetPlayerActiveParams{ID: p2.ID, Active: false})
+				if err != nil {
+					t.Fatalf("Could not deactivate player: %s", err)
+				}
+				allPlayers, _ := conn.Queries().GetAllPlayers(ctx, testutil.GetAllPlayersParams())
+				if len(allPlayers) != 1 || allPlayers[0].Player.ID != p1.ID {
+					t.Fatalf("Expected only the active player, got %v", allPlayers)
+				}
+				p, err := conn.Queries().GetPlayer(ctx, sqlc.GetPlayerParams{DiscordID: "neruh", GuildID: p2.GuildID})
+				if err != nil {
+					t.Fatalf("Could not get deactivated player: %s", err)
+				}
+				if p.Player.Active || p.Debt.Amount != 80000 {
+					t.Fatalf("Expected inactive player with debt of 80000, got %v", p)
+				}
+				withInactive, _ := conn.Queries().GetAllPlayersWithInactive(ctx, testutil.GetAllPlayersParams())
+				if len(withInactive) != 2 {
+					t.Fatalf("Expected the active and the inactive player, got %v", withInactive)
+				}
+			},
+		},
 		{
 			name: "put bot setup and retrieve",
 			withConnection: func(t *testing.T, conn db.Connection, ctx context.Context) {
@@ -95,7 +125,96 @@ func Test_Connection(t *testing.T) {
 			},
 		},
 		{
-			name: "add more than 10 journal entries and retrieve them",
+			name: "bot setup awaits reactions until cleared",
+			withConnection: func(t *testing.T, conn db.Connection, ctx context.Context) {
+				botSetup, _ := conn.Queries().PutBotSetup(
+					ctx, sqlc.PutBotSetupParams{
+						GuildID:               testutil.TestGuildIdString(),
+						ChannelID:             "channel-id",
+						DebtsMessageID:        "debts-message-id",
+						RegistrationMessageID: "registration-message-id",
+					},
+				)
+				if botSetup.AwaitingReactionsUntil.Valid {
+					t.Fatalf("Expected a new bot setup not to await reactions")
+				}
+				until := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+				for _, awaitingUntil := range []pgtype.Timestamp{{Time: until, Valid: true}, {}} {
+					err := conn.Queries().SetBotSetupAwaitingReactions(
+						ctx, sqlc.SetBotSetupAwaitingReactionsParams{
+							GuildID:                testutil.TestGuildIdString(),
+							AwaitingReactionsUntil: awaitingUntil,
+						},
+					)
+					if err != nil {
+						t.Fatalf("Could not set awaiting reactions: %s", err)
+					}
+					botSetup, _ = conn.Queries().GetBotSetup(ctx, testutil.GetBotSetupParams())
+					if botSetup.AwaitingReactionsUntil.Valid != awaitingUntil.Valid ||
+						!botSetup.AwaitingReactionsUntil.Time.Equal(awaitingUntil.Time) {
+						t.Fatalf(
+							"Expected awaiting reactions until %v, got %v",
+							awaitingUntil,
+							botSetup.AwaitingReactionsUntil,
+						)
+					}
+				}
+			},
+		},
+		{
+			name: "orphaned guilds are returned once their retention has passed",
+			withConnection: func(t *testing.T, conn db.Connection, ctx context.Context) {
+				err := conn.Queries().MarkGuildOrphaned(ctx, testutil.TestGuildIdString())
+				if err != nil {
+					t.Fatalf("Could not mark guild orphaned: %s", err)
+				}
+				// marking twice keeps the first time
+				_ = conn.Queries().MarkGuildOrphaned(ctx, testutil.TestGuildIdString())
+				guilds, _ := conn.Queries().GetOrphanedGuilds(
+					ctx,
+					pgtype.Timestamp{Time: time.Now().Add(-time.Hour), Valid: true},
+				)
+				if len(guilds) != 0 {
+					t.Fatalf("Expected no guild to be orphaned an hour ago, got %v", guilds)
+				}
+				guilds, _ = conn.Queries().GetOrphanedGuilds(
+					ctx,
+					pgtype.Timestamp{Time: time.Now().Add(time.Hour), Valid: true},
+				)
+				if len(guilds) != 1 || guilds[0] != testutil.TestGuildIdString() {
+					t.Fatalf("Expected the test guild to be orphaned, got %v", guilds)
+				}
+				_ = conn.Queries().UnmarkGuildOrphaned(ctx, testutil.TestGuildIdString())
+				guilds, _ = conn.Queries().GetOrphanedGuilds(
+					ctx,
+					pgtype.Timestamp{Time: time.Now().Add(time.Hour), Valid: true},
+				)
+				if len(guilds) != 0 {
+					t.Fatalf("Expected no orphaned guild after unmarking, got %v", guilds)
+				}
+			},
+		},
+		{
+			name: "deleting the players of a guild deletes their debts and journal",
+			withConnection: func(t *testing.T, conn db.Connection, ctx context.Context) {
+				p, _ := conn.Queries().AddPlayer(ctx, testutil.AddPlayerParams("torfstack"))
+				_, _ = conn.Queries().AddJournalEntry(ctx, sqlc.AddJournalEntryParams{Amount: 10000, UserID: p.ID})
+				err := conn.Queries().DeletePlayersOfGuild(ctx, testutil.TestGuildIdString())
+				if err != nil {
+					t.Fatalf("Could not delete players of guild: %s", err)
+				}
+				numberOfPlayers, _ := conn.Queries().NumberOfPlayers(ctx)
+				if numberOfPlayers != 0 {
+					t.Fatalf("Expected no players, got %v", numberOfPlayers)
+				}
+				entries, _ := conn.Queries().GetJournalEntries(ctx, p.ID)
+				if len(entries) != 0 {
+					t.Fatalf("Expected no journal entries, got %v", entries)
+				}
+			},
+		},
+		{
+			name: "add more than 10 journal entries and retrieve all of them",
 			withConnection: func(t *testing.T, conn db.Connection, ctx context.Context) {
 				p, _ := conn.Queries().AddPlayer(ctx, testutil.AddPlayerParams("torfstack"))
 				for i := range 5 {
@@ -121,8 +240,78 @@ func Test_Connection(t *testing.T) {
 					)
 				}
 				entries2, _ := conn.Queries().GetJournalEntries(ctx, p.ID)
-				if len(entries2) > 10 {
-					t.Fatalf("Expected 10 journal entries, got %d", len(entries))
+				if len(entries2) != 11 {
+					t.Fatalf("Expected 11 journal entries, got %d", len(entries2))
+				}
+				if entries2[0].Amount != 50000 {
+					t.Fatalf("Expected newest journal entry first, got %d", entries2[0].Amount)
+				}
+			},
+		},
+		{
+			name: "retrieve journal entries of guild and player paginated",
+			withConnection: func(t *testing.T, conn db.Connection, ctx context.Context) {
+				p1, _ := conn.Queries().AddPlayer(ctx, testutil.AddPlayerParams("torfstack"))
+				p2, _ := conn.Queries().AddPlayer(ctx, testutil.AddPlayerParams("neruh"))
+				for i := range 3 {
+					for _, p := range []sqlc.Player{p1, p2} {
+						_, _ = conn.Queries().AddJournalEntry(
+							ctx, sqlc.AddJournalEntryParams{
+								Amount:      int64(i * 10000),
+								Description: fmt.Sprintf("added %v", i*10000),
+								UserID:      p.ID,
+							},
+						)
+					}
+				}
+				guildEntries, err := conn.Queries().GetJournalEntriesOfGuild(
+					ctx, sqlc.GetJournalEntriesOfGuildParams{
+						GuildID: testutil.TestGuildIdString(),
+						Lim:     4,
+						Off:     0,
+					},
+				)
+				if err != nil {
+					t.Fatalf("Could not get journal entries of guild: %s", err)
+				}
+				if len(guildEntries) != 4 || guildEntries[0].DiscordID != "neruh" {
+					t.Fatalf("Expected 4 journal entries starting with neruh, got %v", guildEntries)
+				}
+				playerEntries, err := conn.Queries().GetJournalEntriesOfPlayer(
+					ctx, sqlc.GetJournalEntriesOfPlayerParams{
+						GuildID:   testutil.TestGuildIdString(),
+						DiscordID: "torfstack",
+						Lim:       10,
+						Off:       1,
+					},
+				)
+				if err != nil {
+					t.Fatalf("Could not get journal entries of player: %s", err)
+				}
+				if len(playerEntries) != 2 || playerEntries[0].DebtJournal.Amount != 10000 {
+					t.Fatalf("Expected 2 journal entries starting with 10000, got %v", playerEntries)
+				}
+				_, _ = conn.Queries().AddJournalEntry(
+					ctx, sqlc.AddJournalEntryParams{
+						Amount:      5000,
+						Description: "wipe on trash",
+						UserID:      p1.ID,
+						Category:    "wipe on trash",
+					},
+				)
+				categoryEntries, err := conn.Queries().GetJournalEntriesOfGuild(
+					ctx, sqlc.GetJournalEntriesOfGuildParams{
+						GuildID:  testutil.TestGuildIdString(),
+						Category: "wipe on trash",
+						Lim:      10,
+						Off:      0,
+					},
+				)
+				if err != nil {
+					t.Fatalf("Could not get journal entries of category: %s", err)
+				}
+				if len(categoryEntries) != 1 || categoryEntries[0].DebtJournal.Amount != 5000 {
+					t.Fatalf("Expected 1 journal entry of category, got %v", categoryEntries)
 				}
 			},
 		},
@@ -150,6 +339,215 @@ func Test_Connection(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "put guild settings twice and retrieve latest",
+			withConnection: func(t *testing.T, conn db.Connection, ctx context.Context) {
+				exist, _ := conn.Queries().DoGuildSettingsExist(ctx, testutil.TestGuildIdString())
+				if exist {
+					t.Fatalf("Expected no guild settings before putting them")
+				}
+				for _, amount := range []int64{5000, 25000} {
+					_, err := conn.Queries().PutGuildSettings(
+						ctx, sqlc.PutGuildSettingsParams{
+							GuildID:       testutil.TestGuildIdString(),
+							PenaltyAmount: amount,
+						},
+					)
+					if err != nil {
+						t.Fatalf("Could not put guild settings: %s", err)
+					}
+				}
+				settings, err := conn.Queries().GetGuildSettings(ctx, testutil.TestGuildIdString())
+				if err != nil {
+					t.Fatalf("Could not get guild settings: %s", err)
+				}
+				if settings.PenaltyAmount != 25000 {
+					t.Fatalf("Expected penalty amount of 25000, got %d", settings.PenaltyAmount)
+				}
+			},
+		},
+		{
+			name: "add pending payments and retrieve their sum with all players",
+			withConnection: func(t *testing.T, conn db.Connection, ctx context.Context) {
+				p, _ := conn.Queries().AddPlayer(ctx, testutil.AddPlayerParams("torfstack"))
+				_ = conn.Queries().SetDebt(ctx, sqlc.SetDebtParams{Amount: 50000, UserID: p.ID})
+				payment, err := conn.Queries().AddPendingPayment(
+					ctx, sqlc.AddPendingPaymentParams{
+						UserID: p.ID,
+						Amount: 10000,
+					},
+				)
+				if err != nil {
+					t.Fatalf("Could not add pending payment: %s", err)
+				}
+				_, _ = conn.Queries().AddPendingPayment(ctx, sqlc.AddPendingPaymentParams{UserID: p.ID, Amount: 5000})
+				sum, _ := conn.Queries().GetPendingPaymentSum(ctx, p.ID)
+				if sum != 15000 {
+					t.Fatalf("Expected pending payments of 15000, got %d", sum)
+				}
+				players, _ := conn.Queries().GetAllPlayers(ctx, testutil.TestGuildIdString())
+				if len(players) != 1 || players[0].PendingAmount != 15000 || players[0].Debt.Amount != 50000 {
+					t.Fatalf("Expected player with pending payments of 15000, got %v", players)
+				}
+				row, err := conn.Queries().GetPendingPaymentForUpdate(
+					ctx, sqlc.GetPendingPaymentForUpdateParams{
+						ID:      payment.ID,
+						GuildID: testutil.TestGuildIdString(),
+					},
+				)
+				if err != nil || row.Player.ID != p.ID || row.PendingPayment.Amount != 10000 {
+					t.Fatalf("Expected pending payment of 10000, got %v, %v", row, err)
+				}
+				_, err = conn.Queries().GetPendingPaymentForUpdate(
+					ctx, sqlc.GetPendingPaymentForUpdateParams{
+						ID:      payment.ID,
+						GuildID: "other guild",
+					},
+				)
+				if err == nil {
+					t.Fatalf("Expected no pending payment in other guild")
+				}
+				_ = conn.Queries().DeletePendingPayment(ctx, payment.ID)
+				sum, _ = conn.Queries().GetPendingPaymentSum(ctx, p.ID)
+				if sum != 5000 {
+					t.Fatalf("Expected pending payments of 5000 after deleting one, got %d", sum)
+				}
+			},
+		},
+		{
+			name: "remind players above the threshold once per due time unless they opted out",
+			withConnection: func(t *testing.T, conn db.Connection, ctx context.Context) {
+				p1, _ := conn.Queries().AddPlayer(ctx, testutil.AddPlayerParams("torfstack"))
+				p2, _ := conn.Queries().AddPlayer(ctx, testutil.AddPlayerParams("neruh"))
+				p3, _ := conn.Queries().AddPlayer(ctx, testutil.AddPlayerParams("scurvy"))
+				_ = conn.Queries().SetDebt(ctx, sqlc.SetDebtParams{Amount: 50000, UserID: p1.ID})
+				_ = conn.Queries().SetDebt(ctx, sqlc.SetDebtParams{Amount: 5000, UserID: p2.ID})
+				_ = conn.Queries().SetDebt(ctx, sqlc.SetDebtParams{Amount: 50000, UserID: p3.ID})
+				_ = conn.Queries().AddReminderOptOut(ctx, p3.ID)
+				due := pgtype.Timestamp{Time: time.Date(2024, 3, 11, 18, 0, 0, 0, time.UTC), Valid: true}
+				params := sqlc.GetPlayersToRemindParams{
+					GuildID:   testutil.TestGuildIdString(),
+					Threshold: 10000,
+					DueAt:     due,
+				}
+				players, err := conn.Queries().GetPlayersToRemind(ctx, params)
+				if err != nil {
+					t.Fatalf("Could not get players to remind: %s", err)
+				}
+				if len(players) != 1 || players[0].Player.ID != p1.ID {
+					t.Fatalf("Expected only the player above the threshold to be reminded, got %v", players)
+				}
+				for i, want := range []int64{1, 0} {
+					added, _ := conn.Queries().AddReminder(ctx, sqlc.AddReminderParams{UserID: p1.ID, DueAt: due})
+					if added != want {
+						t.Fatalf("Expected %d reminders to be added on attempt %d, got %d", want, i+1, added)
+					}
+				}
+				players, _ = conn.Queries().GetPlayersToRemind(ctx, params)
+				if len(players) != 0 {
+					t.Fatalf("Expected no players to remind twice, got %v", players)
+				}
+				_ = conn.Queries().DeleteReminderOptOut(ctx, p3.ID)
+				_ = conn.Queries().DeleteRemindersBefore(
+					ctx, sqlc.DeleteRemindersBeforeParams{
+						GuildID: testutil.TestGuildIdString(),
+						DueAt:   pgtype.Timestamp{Time: due.Time.Add(7 * 24 * time.Hour), Valid: true},
+					},
+				)
+				players, _ = conn.Queries().GetPlayersToRemind(ctx, params)
+				if len(players) != 2 {
+					t.Fatalf("Expected both players above the threshold to be reminded again, got %v", players)
+				}
+			},
+		},
+		{
+			name: "get journal entries of guild between two dates",
+			withConnection: func(t *testing.T, conn db.Connection, ctx context.Context) {
+				p, _ := conn.Queries().AddPlayer(ctx, testutil.AddPlayerParams("torfstack"))
+				first, _ := conn.Queries().AddJournalEntry(ctx, sqlc.AddJournalEntryParams{Amount: 10000, UserID: p.ID})
+				second, _ := conn.Queries().AddJournalEntry(ctx, sqlc.AddJournalEntryParams{Amount: -5000, UserID: p.ID})
+				now := time.Now().UTC()
+				entries, err := conn.Queries().GetJournalEntriesOfGuildBetween(
+					ctx, sqlc.GetJournalEntriesOfGuildBetweenParams{
+						GuildID:  testutil.TestGuildIdString(),
+						DateFrom: pgtype.Timestamp{Time: now.Add(-time.Hour), Valid: true},
+						DateTo:   pgtype.Timestamp{Time: now.Add(time.Hour), Valid: true},
+					},
+				)
+				if err != nil {
+					t.Fatalf("Could not get journal entries: %s", err)
+				}
+				if len(entries) != 2 || entries[0].DebtJournal.ID != first.ID || entries[1].DebtJournal.ID != second.ID {
+					t.Fatalf("Expected both entries oldest first, got %v", entries)
+				}
+				if entries[0].DiscordID != p.DiscordID || entries[0].Name != p.Name {
+					t.Fatalf("Expected the entries to include the player, got %v", entries[0])
+				}
+				entries, _ = conn.Queries().GetJournalEntriesOfGuildBetween(
+					ctx, sqlc.GetJournalEntriesOfGuildBetweenParams{
+						GuildID:  testutil.TestGuildIdString(),
+						DateFrom: pgtype.Timestamp{Time: now.Add(time.Hour), Valid: true},
+						DateTo:   pgtype.Timestamp{Time: now.Add(2 * time.Hour), Valid: true},
+					},
+				)
+				if len(entries) != 0 {
+					t.Fatalf("Expected no entries after the journal was written, got %v", entries)
+				}
+			},
+		},
+		{
+			name: "post weekly summary once per due time",
+			withConnection: func(t *testing.T, conn db.Connection, ctx context.Context) {
+				due := pgtype.Timestamp{Time: time.Date(2024, 3, 10, 18, 0, 0, 0, time.UTC), Valid: true}
+				params := sqlc.AddWeeklySummaryParams{GuildID: testutil.TestGuildIdString(), DueAt: due}
+				for i, want := range []int64{1, 0} {
+					added, err := conn.Queries().AddWeeklySummary(ctx, params)
+					if err != nil {
+						t.Fatalf("Could not add weekly summary: %s", err)
+					}
+					if added != want {
+						t.Fatalf("Expected %d summaries to be added on attempt %d, got %d", want, i+1, added)
+					}
+				}
+				_ = conn.Queries().DeleteWeeklySummariesBefore(
+					ctx, sqlc.DeleteWeeklySummariesBeforeParams{
+						GuildID: testutil.TestGuildIdString(),
+						DueAt:   pgtype.Timestamp{Time: due.Time.Add(7 * 24 * time.Hour), Valid: true},
+					},
+				)
+				added, _ := conn.Queries().AddWeeklySummary(ctx, params)
+				if added != 1 {
+					t.Fatalf("Expected the summary to be added again after deleting old ones, got %d", added)
+				}
+			},
+		},
+		{
+			name: "api token is replaced and revoked per guild",
+			withConnection: func(t *testing.T, conn db.Connection, ctx context.Context) {
+				for _, hash := range []string{"first", "second"} {
+					err := conn.Queries().PutApiToken(
+						ctx, sqlc.PutApiTokenParams{GuildID: testutil.TestGuildIdString(), TokenHash: hash},
+					)
+					if err != nil {
+						t.Fatalf("Could not put api token: %s", err)
+					}
+				}
+				if _, err := conn.Queries().GetGuildOfApiToken(ctx, "first"); err == nil {
+					t.Fatalf("Expected the first token to be replaced")
+				}
+				guildId, err := conn.Queries().GetGuildOfApiToken(ctx, "second")
+				if err != nil || guildId != testutil.TestGuildIdString() {
+					t.Fatalf("Expected the second token to belong to the guild, got %q, %v", guildId, err)
+				}
+				deleted, _ := conn.Queries().DeleteApiToken(ctx, testutil.TestGuildIdString())
+				if deleted != 1 {
+					t.Fatalf("Expected the token to be deleted, got %d", deleted)
+				}
+				if _, err = conn.Queries().GetGuildOfApiToken(ctx, "second"); err == nil {
+					t.Fatalf("Expected the token to be revoked")
+				}
+			},
+		},
 		{
 			name: "can not add player with same name discord_id and guild_id twice",
 			withConnection: func(t *testing.T, conn db.Connection, ctx context.Context) {
@@ -199,7 +597,11 @@ func Test_Connection(t *testing.T) {
 					},
 				)
 
-				d := db.NewDatabase(connStr)
+				d, err := db.NewDatabase(ctx, connStr)
+				if err != nil {
+					t.Fatalf("Could not create database: %s", err)
+				}
+				defer d.Close()
 				conn, err := d.Connect(ctx)
 				if err != nil {
 					t.Fatalf("Could not get connection: %s", err)
@@ -212,6 +614,102 @@ func Test_Connection(t *testing.T) {
 	}
 }
 
+func Test_ConcurrentDebtChanges(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping db test")
+		return
+	}
+	ctx := context.Background()
+	cont, err := setupDatabase(t)
+	if err != nil {
+		t.Fatalf("Could not set up database: %s", err)
+		return
+	}
+	t.Cleanup(
+		func() {
+			err = cont.Terminate(ctx)
+			if err != nil {
+				t.Fatalf("Could not terminate database: %s", err)
+			}
+		},
+	)
+	connStr, err := cont.ConnectionString(ctx)
+	if err != nil {
+		t.Fatalf("Could not get connection string: %s", err)
+	}
+
+	d, err := db.NewDatabase(ctx, connStr, db.WithMaxConns(10))
+	if err != nil {
+		t.Fatalf("Could not create database: %s", err)
+	}
+	defer d.Close()
+	service := domain.NewSlashTenK(d)
+	guildId := testutil.TestGuildIdString()
+	err = service.AddPlayer(ctx, "torfstack", "torfstack", guildId, "Torfstack")
+	if err != nil {
+		t.Fatalf("Could not add player: %s", err)
+	}
+
+	const calls = 50
+	var wg sync.WaitGroup
+	for range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := service.AddDebt(ctx, "torfstack", guildId, 1000, "", "concurrent"); err != nil {
+				t.Errorf("Could not add debt: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	player, err := service.GetPlayer(ctx, "torfstack", guildId)
+	if err != nil {
+		t.Fatalf("Could not get player: %s", err)
+	}
+	if player.Debt.Amount != calls*1000 {
+		t.Fatalf("Expected debt of %d, got %d", calls*1000, player.Debt.Amount)
+	}
+
+	for i := range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var err error
+			if i%5 == 0 {
+				err = service.ResetDebt(ctx, "torfstack", guildId, "concurrent")
+			} else {
+				err = service.AddDebt(ctx, "torfstack", guildId, 1000, "", "concurrent")
+			}
+			if err != nil {
+				t.Errorf("Could not change debt: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	player, err = service.GetPlayer(ctx, "torfstack", guildId)
+	if err != nil {
+		t.Fatalf("Could not get player: %s", err)
+	}
+	conn, err := d.Connect(ctx)
+	if err != nil {
+		t.Fatalf("Could not get connection: %s", err)
+	}
+	defer conn.Close(ctx)
+	entries, err := conn.Queries().GetJournalEntries(ctx, player.Id)
+	if err != nil {
+		t.Fatalf("Could not get journal entries: %s", err)
+	}
+	var sum int64
+	for _, entry := range entries {
+		sum += entry.Amount
+	}
+	if player.Debt.Amount != sum {
+		t.Fatalf("Expected debt to match the journal sum of %d, got %d", sum, player.Debt.Amount)
+	}
+}
+
 func setupDatabase(t *testing.T) (*postgres.PostgresContainer, error) {
 	ctx := context.Background()
 
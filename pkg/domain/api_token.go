@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	sqlc "slash10k/sql/gen"
+)
+
+// ApiTokenBytes is the number of random bytes of an api token.
+const ApiTokenBytes = 32
+
+// CreateApiToken replaces the api token of the guild with a new one. Only a hash of the token is
+// stored, so the returned token can not be shown again.
+func (s service) CreateApiToken(ctx context.Context, guildId string) (string, error) {
+	b := make([]byte, ApiTokenBytes)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("could not generate api token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	err = conn.Queries().PutApiToken(
+		ctx, sqlc.PutApiTokenParams{
+			GuildID:   guildId,
+			TokenHash: hashApiToken(token),
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return token, nil
+}
+
+// RevokeApiToken deletes the api token of the guild and reports whether it had one.
+func (s service) RevokeApiToken(ctx context.Context, guildId string) (bool, error) {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	deleted, err := conn.Queries().DeleteApiToken(ctx, guildId)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return deleted > 0, nil
+}
+
+// GetGuildOfApiToken returns the guild the token was created for.
+func (s service) GetGuildOfApiToken(ctx context.Context, token string) (string, error) {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	guildId, err := conn.Queries().GetGuildOfApiToken(ctx, hashApiToken(token))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrApiTokenDoesNotExist
+	} else if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return guildId, nil
+}
+
+func hashApiToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,55 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/mock/gomock"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/testutil"
+	sqlc "slash10k/sql/gen"
+	"testing"
+)
+
+func Test_ApiToken(t *testing.T) {
+	c := gomock.NewController(t)
+	mockDb, mockQueries := testutil.QueriesMock(c)
+	var hash string
+	mockQueries.EXPECT().
+		PutApiToken(gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(_ context.Context, params sqlc.PutApiTokenParams) error {
+				hash = params.TokenHash
+				return nil
+			},
+		)
+	mockQueries.EXPECT().
+		GetGuildOfApiToken(gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(_ context.Context, tokenHash string) (string, error) {
+				if tokenHash != hash {
+					return "", pgx.ErrNoRows
+				}
+				return testutil.TestGuildIdString(), nil
+			},
+		).
+		Times(2)
+
+	service := domain.NewSlashTenK(mockDb)
+	token, err := service.CreateApiToken(context.Background(), testutil.TestGuildIdString())
+	testutil.WithoutError(t, token, err)
+	if token == "" || hash == "" || hash == token {
+		t.Fatalf("Expected only the hash of the token to be stored, got token %q and hash %q", token, hash)
+	}
+
+	guildId, err := service.GetGuildOfApiToken(context.Background(), token)
+	testutil.WithoutError(t, guildId, err)
+	if guildId != testutil.TestGuildIdString() {
+		t.Errorf("Expected the token to belong to guild %s, got %s", testutil.TestGuildIdString(), guildId)
+	}
+
+	_, err = service.GetGuildOfApiToken(context.Background(), token+"x")
+	if !errors.Is(err, domain.ErrApiTokenDoesNotExist) {
+		t.Errorf("Expected ErrApiTokenDoesNotExist for an unknown token, got %v", err)
+	}
+}
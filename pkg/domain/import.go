@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slash10k/pkg/convert/fromdb"
+	"slash10k/pkg/models"
+	sqlc "slash10k/sql/gen"
+	"strings"
+)
+
+// PlanImport matches the rows with the players of the guild, by discord id first and by name second,
+// without changing anything. Names match inactive players only if no active player has the name. Rows
+// that match no player, or several players by name, are skipped.
+func (s service) PlanImport(ctx context.Context, guildId string, rows []models.ImportRow) ([]models.ImportChange, error) {
+	players, err := s.GetAllPlayersWithInactive(ctx, guildId)
+	if err != nil {
+		return nil, err
+	}
+	return planImport(players, rows)
+}
+
+func planImport(players []models.Player, rows []models.ImportRow) ([]models.ImportChange, error) {
+	byDiscordId := make(map[string]models.Player, len(players))
+	byName := make(map[string][]models.Player, len(players))
+	inactiveByName := make(map[string][]models.Player)
+	for _, player := range players {
+		byDiscordId[player.DiscordId] = player
+		name := strings.ToLower(player.Name)
+		if player.Active {
+			byName[name] = append(byName[name], player)
+		} else {
+			inactiveByName[name] = append(inactiveByName[name], player)
+		}
+	}
+
+	changes := make([]models.ImportChange, len(rows))
+	for i, row := range rows {
+		changes[i].Row = row
+		player, ok := byDiscordId[row.Player]
+		if !ok {
+			matches := byName[strings.ToLower(row.Player)]
+			if len(matches) == 0 {
+				matches = inactiveByName[strings.ToLower(row.Player)]
+			}
+			if len(matches) != 1 {
+				continue
+			}
+			player = matches[0]
+		}
+		changes[i].Player = player
+	}
+	return changes, checkImportDuplicates(changes)
+}
+
+// checkImportDuplicates makes sure that no player is changed by two rows, it is unclear which one is meant.
+func checkImportDuplicates(changes []models.ImportChange) error {
+	lines := make(map[string]int, len(changes))
+	for _, change := range changes {
+		if change.Skipped() {
+			continue
+		}
+		if line, ok := lines[change.Player.DiscordId]; ok {
+			return fmt.Errorf("%w: %s in lines %d and %d", ErrDuplicateImportRow, change.Player.Name, line, change.Row.Line)
+		}
+		lines[change.Player.DiscordId] = change.Row.Line
+	}
+	return nil
+}
+
+// Import sets the debts of the players to the amounts of the rows and records the differences in the
+// journal, all in a single transaction. New players are registered and inactive players reactivated
+// first, skipped rows are ignored. As they did not react to the registration message, the guild awaits
+// their reactions then, see models.BotSetup. The differences are taken from the debts at the time of the
+// import, not at the time of the plan.
+func (s service) Import(ctx context.Context, guildId string, changes []models.ImportChange) error {
+	err := checkImportDuplicates(changes)
+	if err != nil {
+		return err
+	}
+
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	tx, err := conn.StartTransaction(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	queries := tx.Queries()
+	registered := false
+	for _, change := range changes {
+		if change.Skipped() {
+			continue
+		}
+		if change.New || !change.Player.Active {
+			registered = true
+			err = registerPlayer(
+				ctx,
+				queries,
+				change.Player.DiscordId,
+				change.Player.DiscordName,
+				guildId,
+				change.Player.Name,
+			)
+			if err != nil && !errors.Is(err, ErrPlayerAlreadyExists) {
+				return err
+			}
+		}
+
+		player, err := queries.GetPlayerForUpdate(
+			ctx, sqlc.GetPlayerForUpdateParams{
+				DiscordID: change.Player.DiscordId,
+				GuildID:   guildId,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrDatabase, err)
+		}
+
+		err = setDebtWithJournal(
+			ctx,
+			queries,
+			fromdb.FromLockedPlayerWithDebt(player),
+			change.Row.Amount,
+			"",
+			change.Description,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	if registered {
+		err = queries.SetBotSetupAwaitingReactions(ctx, awaitingReactionsParams(guildId, true))
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrDatabase, err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/mock/gomock"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/models"
+	"slash10k/pkg/testutil"
+	sqlc "slash10k/sql/gen"
+	"testing"
+	"time"
+)
+
+func Test_PlanImport(t *testing.T) {
+	player := func(id int32, discordId string, name string, debt int64, active bool) sqlc.GetAllPlayersWithInactiveRow {
+		return sqlc.GetAllPlayersWithInactiveRow{
+			Player: sqlc.Player{
+				ID:        id,
+				DiscordID: discordId,
+				Name:      name,
+				GuildID:   testutil.TestGuildIdString(),
+				Active:    active,
+			},
+			Debt: sqlc.Debt{UserID: id, Amount: debt},
+		}
+	}
+	players := []sqlc.GetAllPlayersWithInactiveRow{
+		player(1, "1", "torfstack", 30000, true),
+		player(2, "2", "neruh", 0, true),
+		player(3, "3", "Twin", 0, true),
+		player(4, "4", "twin", 0, true),
+		player(5, "5", "neruh", 10000, false),
+		player(6, "6", "scurvy", 20000, false),
+	}
+	tests := []struct {
+		name        string
+		rows        []models.ImportRow
+		wantPlayers []string
+		wantErr     error
+	}{
+		{
+			name: "by discord id and name",
+			rows: []models.ImportRow{
+				{Line: 1, Player: "1", Amount: 10000},
+				{Line: 2, Player: "NERUH", Amount: 5000},
+			},
+			wantPlayers: []string{"1", "2"},
+		},
+		{
+			name: "unknown and ambiguous names are skipped",
+			rows: []models.ImportRow{
+				{Line: 1, Player: "bob"},
+				{Line: 2, Player: "twin"},
+			},
+			wantPlayers: []string{"", ""},
+		},
+		{
+			name: "inactive players with their debt",
+			rows: []models.ImportRow{
+				{Line: 1, Player: "scurvy", Amount: 5000},
+				{Line: 2, Player: "5", Amount: 5000},
+			},
+			wantPlayers: []string{"6", "5"},
+		},
+		{
+			name: "the same player twice",
+			rows: []models.ImportRow{
+				{Line: 1, Player: "1"},
+				{Line: 2, Player: "torfstack"},
+			},
+			wantErr: domain.ErrDuplicateImportRow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				c := gomock.NewController(t)
+				mockDb, mockQueries := testutil.QueriesMock(c)
+				mockQueries.EXPECT().
+					GetAllPlayersWithInactive(gomock.Any(), testutil.TestGuildIdString()).
+					Return(players, nil)
+
+				changes, err := domain.NewSlashTenK(mockDb).PlanImport(
+					context.Background(),
+					testutil.TestGuildIdString(),
+					tt.rows,
+				)
+				if tt.wantErr != nil {
+					if !errors.Is(err, tt.wantErr) {
+						t.Fatalf("PlanImport() error = %v, want %v", err, tt.wantErr)
+					}
+					return
+				}
+				testutil.WithoutError(t, changes, err)
+				if len(changes) != len(tt.wantPlayers) {
+					t.Fatalf("Expected %d changes, got %d", len(tt.wantPlayers), len(changes))
+				}
+				for i, change := range changes {
+					if change.Player.DiscordId != tt.wantPlayers[i] || change.Row != tt.rows[i] {
+						t.Errorf("Expected row %d to match player %q, got %v", i+1, tt.wantPlayers[i], change)
+					}
+				}
+			},
+		)
+	}
+}
+
+func Test_Import(t *testing.T) {
+	c := gomock.NewController(t)
+	mockDb, mockQueries := testutil.QueriesMock(c)
+	locked := func(id int32, discordId string, debt int64) sqlc.GetPlayerForUpdateRow {
+		return sqlc.GetPlayerForUpdateRow{
+			Player: sqlc.Player{ID: id, DiscordID: discordId, GuildID: testutil.TestGuildIdString()},
+			Debt:   sqlc.Debt{UserID: id, Amount: debt},
+		}
+	}
+	gomock.InOrder(
+		mockQueries.EXPECT().
+			GetPlayerForUpdate(gomock.Any(), sqlc.GetPlayerForUpdateParams{DiscordID: "1", GuildID: testutil.TestGuildIdString()}).
+			Return(locked(1, "1", 20000), nil),
+		mockQueries.EXPECT().
+			SetDebt(gomock.Any(), sqlc.SetDebtParams{UserID: 1, Amount: 50000}),
+		mockQueries.EXPECT().
+			AddJournalEntry(
+				gomock.Any(),
+				sqlc.AddJournalEntryParams{UserID: 1, Amount: 30000, Description: "imported"},
+			),
+		mockQueries.EXPECT().
+			DoesPlayerExist(gomock.Any(), sqlc.DoesPlayerExistParams{DiscordID: "2", GuildID: testutil.TestGuildIdString()}).
+			Return(false, nil),
+		mockQueries.EXPECT().
+			AddPlayer(
+				gomock.Any(),
+				sqlc.AddPlayerParams{
+					DiscordID:   "2",
+					DiscordName: "neruh",
+					GuildID:     testutil.TestGuildIdString(),
+					Name:        "Neruh",
+				},
+			),
+		mockQueries.EXPECT().
+			GetPlayerForUpdate(gomock.Any(), sqlc.GetPlayerForUpdateParams{DiscordID: "2", GuildID: testutil.TestGuildIdString()}).
+			Return(locked(2, "2", 0), nil),
+		mockQueries.EXPECT().
+			SetDebt(gomock.Any(), sqlc.SetDebtParams{UserID: 2, Amount: 5000}),
+		mockQueries.EXPECT().
+			AddJournalEntry(
+				gomock.Any(),
+				sqlc.AddJournalEntryParams{UserID: 2, Amount: 5000, Description: "imported: old sheet"},
+			),
+		mockQueries.EXPECT().
+			SetBotSetupAwaitingReactions(gomock.Any(), gomock.Any()).
+			DoAndReturn(
+				func(_ context.Context, params sqlc.SetBotSetupAwaitingReactionsParams) error {
+					if params.GuildID != testutil.TestGuildIdString() || !params.AwaitingReactionsUntil.Time.After(time.Now()) {
+						t.Errorf("Expected the guild to await the reaction of the new player, got %v", params)
+					}
+					return nil
+				},
+			),
+	)
+
+	err := domain.NewSlashTenK(mockDb).Import(
+		context.Background(), testutil.TestGuildIdString(), []models.ImportChange{
+			{
+				Row:         models.ImportRow{Line: 1, Player: "torfstack", Amount: 50000},
+				Player:      models.Player{DiscordId: "1", Active: true, Debt: models.Debt{Amount: 10000}},
+				Description: "imported",
+			},
+			{
+				Row: models.ImportRow{Line: 2, Player: "scurvy", Amount: 10000},
+			},
+			{
+				Row:         models.ImportRow{Line: 3, Player: "2", Amount: 5000, Note: "old sheet"},
+				Player:      models.Player{DiscordId: "2", DiscordName: "neruh", Name: "Neruh"},
+				New:         true,
+				Description: "imported: old sheet",
+			},
+		},
+	)
+	testutil.WithoutError(t, nil, err)
+}
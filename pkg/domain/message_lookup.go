@@ -7,11 +7,14 @@ import (
 
 type MessageLookup interface {
 	IsRegistrationMessage(messageId string) bool
+	IsBoardChannel(channelId string) bool
 	AddSetup(botSetup models.BotSetup)
+	RemoveSetup(botSetup models.BotSetup)
 }
 
 type messageLookup struct {
-	set utils.SyncSet[string]
+	set      utils.SyncSet[string]
+	channels utils.SyncSet[string]
 }
 
 var _ MessageLookup = (*messageLookup)(nil)
@@ -19,9 +22,9 @@ var _ MessageLookup = (*messageLookup)(nil)
 func NewMessageLookup(
 	botSetups []models.BotSetup,
 ) *messageLookup {
-	m := messageLookup{set: utils.NewSyncSet[string]()}
+	m := messageLookup{set: utils.NewSyncSet[string](), channels: utils.NewSyncSet[string]()}
 	for _, botSetup := range botSetups {
-		m.set.Add(botSetup.RegistrationMessageId)
+		m.AddSetup(botSetup)
 	}
 	return &m
 }
@@ -30,6 +33,17 @@ func (m *messageLookup) IsRegistrationMessage(messageId string) bool {
 	return m.set.Contains(messageId)
 }
 
+// IsBoardChannel reports whether the channel holds the messages of a bot setup.
+func (m *messageLookup) IsBoardChannel(channelId string) bool {
+	return m.channels.Contains(channelId)
+}
+
 func (m *messageLookup) AddSetup(botSetup models.BotSetup) {
 	m.set.Add(botSetup.RegistrationMessageId)
+	m.channels.Add(botSetup.ChannelId)
+}
+
+func (m *messageLookup) RemoveSetup(botSetup models.BotSetup) {
+	m.set.Remove(botSetup.RegistrationMessageId)
+	m.channels.Remove(botSetup.ChannelId)
 }
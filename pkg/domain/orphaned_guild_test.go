@@ -0,0 +1,33 @@
+package domain_test
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/mock/gomock"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/testutil"
+	"testing"
+	"time"
+)
+
+func Test_GetOrphanedGuilds(t *testing.T) {
+	c := gomock.NewController(t)
+	mockDb, mockQueries := testutil.QueriesMock(c)
+	orphanedBefore := time.Date(2024, 3, 1, 18, 0, 0, 0, time.FixedZone("CET", 60*60))
+	mockQueries.EXPECT().
+		GetOrphanedGuilds(gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(_ context.Context, before pgtype.Timestamp) ([]string, error) {
+				if want := time.Date(2024, 3, 1, 17, 0, 0, 0, time.UTC); before.Time != want {
+					t.Errorf("Expected the cutoff %v in UTC, got %v", want, before.Time)
+				}
+				return []string{testutil.TestGuildIdString()}, nil
+			},
+		)
+
+	guildIds, err := domain.NewSlashTenK(mockDb).GetOrphanedGuilds(context.Background(), orphanedBefore)
+	testutil.WithoutError(t, guildIds, err)
+	if len(guildIds) != 1 || guildIds[0] != testutil.TestGuildIdString() {
+		t.Errorf("Expected the orphaned guild, got %v", guildIds)
+	}
+}
@@ -0,0 +1,119 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/mock/gomock"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/testutil"
+	sqlc "slash10k/sql/gen"
+	"testing"
+)
+
+func Test_ApprovePayment(t *testing.T) {
+	pending := sqlc.GetPendingPaymentForUpdateRow{
+		PendingPayment: sqlc.PendingPayment{ID: 7, UserID: 1, Amount: 20000},
+		Player:         sqlc.Player{ID: 1, DiscordID: "1", GuildID: testutil.TestGuildIdString()},
+	}
+	tests := []struct {
+		name        string
+		pendingErr  error
+		debt        int64
+		wantErr     error
+		wantPayment bool
+	}{
+		{name: "already handled", pendingErr: pgx.ErrNoRows, wantErr: domain.ErrPendingPaymentDoesNotExist},
+		{name: "database error", pendingErr: errors.New("connection reset"), wantErr: domain.ErrDatabase},
+		{name: "exceeds corrected debt", debt: 10000, wantErr: domain.ErrAmountExceedsDebt, wantPayment: true},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				c := gomock.NewController(t)
+				mockDb, mockQueries := testutil.QueriesMock(c)
+				mockQueries.EXPECT().
+					GetPendingPaymentForUpdate(
+						gomock.Any(),
+						sqlc.GetPendingPaymentForUpdateParams{ID: 7, GuildID: testutil.TestGuildIdString()},
+					).
+					Return(pending, tt.pendingErr)
+				if tt.pendingErr == nil {
+					mockQueries.EXPECT().DeletePendingPayment(gomock.Any(), int32(7))
+					mockQueries.EXPECT().
+						GetPlayerForUpdate(
+							gomock.Any(),
+							sqlc.GetPlayerForUpdateParams{DiscordID: "1", GuildID: testutil.TestGuildIdString()},
+						).
+						Return(
+							sqlc.GetPlayerForUpdateRow{
+								Player: pending.Player,
+								Debt:   sqlc.Debt{UserID: 1, Amount: tt.debt},
+							}, nil,
+						)
+				}
+
+				payment, err := domain.NewSlashTenK(mockDb).ApprovePayment(
+					context.Background(),
+					testutil.TestGuildIdString(),
+					7,
+					"approved",
+				)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ApprovePayment() error = %v, want %v", err, tt.wantErr)
+				}
+				if tt.wantErr != domain.ErrPendingPaymentDoesNotExist && errors.Is(err, domain.ErrPendingPaymentDoesNotExist) {
+					t.Fatalf("Expected %v not to be reported as already handled", err)
+				}
+				if (payment != nil) != tt.wantPayment {
+					t.Fatalf("Expected payment %v, got %v", tt.wantPayment, payment)
+				}
+			},
+		)
+	}
+}
+
+func Test_PayDebt(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  int64
+		wantErr error
+	}{
+		{name: "within the debt that is not pending", amount: 5000},
+		{name: "exceeds the debt that is not pending", amount: 10000, wantErr: domain.ErrAmountExceedsDebt},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				c := gomock.NewController(t)
+				mockDb, mockQueries := testutil.QueriesMock(c)
+				mockQueries.EXPECT().
+					GetPlayerForUpdate(
+						gomock.Any(),
+						sqlc.GetPlayerForUpdateParams{DiscordID: "1", GuildID: testutil.TestGuildIdString()},
+					).
+					Return(
+						sqlc.GetPlayerForUpdateRow{
+							Player: sqlc.Player{ID: 1, DiscordID: "1", GuildID: testutil.TestGuildIdString()},
+							Debt:   sqlc.Debt{UserID: 1, Amount: 15000},
+						}, nil,
+					)
+				mockQueries.EXPECT().GetPendingPaymentSum(gomock.Any(), int32(1)).Return(int64(10000), nil)
+				if tt.wantErr == nil {
+					mockQueries.EXPECT().SetDebt(gomock.Any(), testutil.SetDebtParams(1, 10000))
+					mockQueries.EXPECT().AddJournalEntry(gomock.Any(), gomock.Any())
+				}
+
+				err := domain.NewSlashTenK(mockDb).PayDebt(
+					context.Background(),
+					"1",
+					testutil.TestGuildIdString(),
+					tt.amount,
+				)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("PayDebt() error = %v, want %v", err, tt.wantErr)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,41 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/mock/gomock"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/testutil"
+	sqlc "slash10k/sql/gen"
+	"testing"
+)
+
+func Test_GetPlayer(t *testing.T) {
+	c := gomock.NewController(t)
+	mockDb, mockQueries := testutil.QueriesMock(c)
+	mockQueries.EXPECT().
+		GetPlayer(gomock.Any(), sqlc.GetPlayerParams{DiscordID: "1", GuildID: testutil.TestGuildIdString()}).
+		Return(
+			sqlc.GetPlayerRow{
+				Player: sqlc.Player{ID: 1, DiscordID: "1", GuildID: testutil.TestGuildIdString()},
+				Debt:   sqlc.Debt{UserID: 1, Amount: 30000},
+			}, nil,
+		)
+	mockQueries.EXPECT().GetPendingPaymentSum(gomock.Any(), int32(1)).Return(int64(10000), nil)
+	mockQueries.EXPECT().
+		GetPlayer(gomock.Any(), sqlc.GetPlayerParams{DiscordID: "2", GuildID: testutil.TestGuildIdString()}).
+		Return(sqlc.GetPlayerRow{}, pgx.ErrNoRows)
+
+	service := domain.NewSlashTenK(mockDb)
+	player, err := service.GetPlayer(context.Background(), "1", testutil.TestGuildIdString())
+	testutil.WithoutError(t, player, err)
+	if player.Active || player.Debt.Amount != 30000 || player.PendingPayment != 10000 {
+		t.Errorf("Expected the inactive player with debt and pending payment, got %v", player)
+	}
+
+	_, err = service.GetPlayer(context.Background(), "2", testutil.TestGuildIdString())
+	if !errors.Is(err, domain.ErrPlayerDoesNotExist) {
+		t.Errorf("Expected ErrPlayerDoesNotExist for an unknown player, got %v", err)
+	}
+}
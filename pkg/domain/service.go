@@ -4,20 +4,59 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"slash10k/pkg/convert/fromdb"
 	"slash10k/pkg/db"
+	"slash10k/pkg/i18n"
 	"slash10k/pkg/models"
 	sqlc "slash10k/sql/gen"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 type Service interface {
 	AddPlayer(ctx context.Context, discordId string, discordName string, guildId string, nick string) error
+	DeactivatePlayer(ctx context.Context, discordId string, guildId string) error
+	UpdatePlayerName(ctx context.Context, discordId string, guildId string, discordName string, nick string) (bool, error)
 	DeletePlayer(ctx context.Context, discordId string, guildId string) error
 	GetAllPlayers(ctx context.Context, guildId string) ([]models.Player, error)
+	GetAllPlayersWithInactive(ctx context.Context, guildId string) ([]models.Player, error)
 	GetPlayer(ctx context.Context, discordId string, guildId string) (*models.Player, error)
 
-	AddDebt(ctx context.Context, discordId string, guildId string, amount int64) error
-	ResetDebt(ctx context.Context, discordId string, guildId string) error
+	AddDebt(
+		ctx context.Context,
+		discordId string,
+		guildId string,
+		amount int64,
+		category string,
+		description string,
+	) error
+	ResetDebt(ctx context.Context, discordId string, guildId string, description string) error
+	PayDebt(ctx context.Context, discordId string, guildId string, amount int64) error
+	RequestPayment(ctx context.Context, discordId string, guildId string, amount int64) (*models.PendingPayment, error)
+	ApprovePayment(ctx context.Context, guildId string, id int32, description string) (*models.PendingPayment, error)
+	RejectPayment(ctx context.Context, guildId string, id int32) (*models.PendingPayment, error)
+	CorrectDebt(ctx context.Context, discordId string, guildId string, amount int64, description string) error
+
+	GetHistory(
+		ctx context.Context,
+		guildId string,
+		discordId string,
+		category string,
+		limit int32,
+		offset int32,
+	) ([]models.DebtJournalEntry, error)
+	GetJournal(ctx context.Context, guildId string, from time.Time, to time.Time) ([]models.DebtJournalEntry, error)
+	GetWeeklySummary(ctx context.Context, guildId string, end time.Time) (*models.WeeklySummary, error)
+
+	PlanImport(ctx context.Context, guildId string, rows []models.ImportRow) ([]models.ImportChange, error)
+	Import(ctx context.Context, guildId string, changes []models.ImportChange) error
+
+	CreateApiToken(ctx context.Context, guildId string) (string, error)
+	RevokeApiToken(ctx context.Context, guildId string) (bool, error)
+	GetGuildOfApiToken(ctx context.Context, token string) (string, error)
 
 	SetBotSetup(
 		ctx context.Context,
@@ -26,9 +65,35 @@ type Service interface {
 		registrationMessageId string,
 		debtsMessageId string,
 	) error
+	UpdateBotSetupMessages(
+		ctx context.Context,
+		guildId string,
+		registrationMessageId string,
+		debtsMessageId string,
+	) error
+	SetAwaitingReactions(ctx context.Context, guildId string, awaiting bool) error
 	GetBotSetup(ctx context.Context, guildId string) (*models.BotSetup, error)
 	GetAllBotSetups(ctx context.Context) ([]models.BotSetup, error)
 	DeleteBotSetup(ctx context.Context, guildId string) error
+
+	GetGuildSettings(ctx context.Context, guildId string) (*models.GuildSettings, error)
+	UpdateGuildSettings(ctx context.Context, settings models.GuildSettings) error
+
+	GetPenaltyCategories(ctx context.Context, guildId string) ([]models.PenaltyCategory, error)
+	GetPenaltyCategory(ctx context.Context, guildId string, id int32) (*models.PenaltyCategory, error)
+	AddPenaltyCategory(ctx context.Context, guildId string, name string, amount int64) error
+	DeletePenaltyCategory(ctx context.Context, guildId string, name string) error
+
+	GetGuildSettingsWithReminders(ctx context.Context) ([]models.GuildSettings, error)
+	ClaimDebtReminders(ctx context.Context, guildId string, threshold int64, dueAt time.Time) ([]models.Player, error)
+	SetRemindersEnabled(ctx context.Context, discordId string, guildId string, enabled bool) error
+	GetGuildSettingsWithSummaries(ctx context.Context) ([]models.GuildSettings, error)
+	ClaimWeeklySummary(ctx context.Context, guildId string, dueAt time.Time) (bool, error)
+
+	MarkGuildOrphaned(ctx context.Context, guildId string) error
+	UnmarkGuildOrphaned(ctx context.Context, guildId string) error
+	GetOrphanedGuilds(ctx context.Context, orphanedBefore time.Time) ([]string, error)
+	PurgeGuild(ctx context.Context, guildId string) error
 }
 
 var (
@@ -36,8 +101,50 @@ var (
 	ErrPlayerAlreadyExists = errors.New("player already exists")
 	ErrPlayerDoesNotExist  = errors.New("player does not exist")
 
+	ErrInvalidAmount     = errors.New("amount must be positive")
+	ErrAmountExceedsDebt = errors.New("amount exceeds debt")
+
+	ErrPendingPaymentDoesNotExist = errors.New("pending payment does not exist")
+
+	ErrDuplicateImportRow = errors.New("player is imported twice")
+
+	ErrApiTokenDoesNotExist = errors.New("api token does not exist")
+
 	ErrBotSetupAlreadyExists = errors.New("bot-setup already exists")
 	ErrBotSetupDoesNotExist  = errors.New("bot-setup does not exist")
+
+	ErrInvalidGuildSettings = errors.New("invalid guild settings")
+
+	ErrPenaltyCategoryAlreadyExists = errors.New("penalty category already exists")
+	ErrPenaltyCategoryDoesNotExist  = errors.New("penalty category does not exist")
+	ErrTooManyPenaltyCategories     = errors.New("too many penalty categories")
+	ErrInvalidPenaltyCategoryName   = errors.New("invalid penalty category name")
+)
+
+const (
+	// The descriptions of the journal entries of payments and penalties, the weekly summary tells them
+	// apart from corrections, resets and imports by these.
+	JournalDescriptionPayment         = "payment"
+	JournalDescriptionApprovedPayment = "payment approved by %s"
+	JournalDescriptionPenalty         = "penalty added by %s"
+
+	// MaxPenaltyCategories leaves room for the default penalty in a select menu,
+	// which Discord limits to 25 options.
+	MaxPenaltyCategories = 24
+	// MaxPenaltyCategoryNameLength keeps category names short enough to be part of component ids,
+	// which Discord limits to 100 characters.
+	MaxPenaltyCategoryNameLength = 50
+
+	MinutesPerDay = 24 * 60
+
+	// MaxBoardTitleLength leaves room for the penalty amount in the title of the board,
+	// which Discord limits to 256 characters.
+	MaxBoardTitleLength       = 200
+	MaxBoardColor       int32 = 0xFFFFFF
+
+	// AwaitingReactionsGracePeriod is how long players without reaction are kept after the registration
+	// message was restored or its emoji changed, see models.BotSetup.
+	AwaitingReactionsGracePeriod = 7 * 24 * time.Hour
 )
 
 type service struct {
@@ -50,6 +157,8 @@ func NewSlashTenK(db db.Database) Service {
 	return &service{db: db}
 }
 
+// AddPlayer registers a new player, or reactivates the player with its previous debt
+// and journal if it has been deactivated before.
 func (s service) AddPlayer(
 	ctx context.Context,
 	discordId string,
@@ -68,7 +177,30 @@ func (s service) AddPlayer(
 		return fmt.Errorf("%w: %s", ErrDatabase, err)
 	}
 
-	doesAlreadyExist, err := tx.Queries().DoesPlayerExist(
+	err = registerPlayer(ctx, tx.Queries(), discordId, discordName, guildId, nick)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return nil
+}
+
+// registerPlayer adds the player, or activates it again if it is known from before, so that
+// its debt is kept. It fails with ErrPlayerAlreadyExists if the player is active.
+func registerPlayer(
+	ctx context.Context,
+	queries db.Queries,
+	discordId string,
+	discordName string,
+	guildId string,
+	nick string,
+) error {
+	doesAlreadyExist, err := queries.DoesPlayerExist(
 		ctx,
 		sqlc.DoesPlayerExistParams{DiscordID: discordId, GuildID: guildId},
 	)
@@ -76,10 +208,32 @@ func (s service) AddPlayer(
 		return fmt.Errorf("%w: %s", ErrDatabase, err)
 	}
 	if doesAlreadyExist {
-		return fmt.Errorf("%w: %s(%s)@%s", ErrPlayerAlreadyExists, discordName, discordId, guildId)
+		player, err := queries.GetPlayer(
+			ctx, sqlc.GetPlayerParams{
+				DiscordID: discordId,
+				GuildID:   guildId,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrDatabase, err)
+		}
+		if player.Player.Active {
+			return fmt.Errorf("%w: %s(%s)@%s", ErrPlayerAlreadyExists, discordName, discordId, guildId)
+		}
+
+		err = queries.SetPlayerActive(
+			ctx, sqlc.SetPlayerActiveParams{
+				ID:     player.Player.ID,
+				Active: true,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrDatabase, err)
+		}
+		return nil
 	}
 
-	_, err = tx.Queries().AddPlayer(
+	_, err = queries.AddPlayer(
 		ctx, sqlc.AddPlayerParams{
 			DiscordID:   discordId,
 			DiscordName: discordName,
@@ -91,6 +245,54 @@ func (s service) AddPlayer(
 		return fmt.Errorf("%w: %s", ErrDatabase, err)
 	}
 
+	return nil
+}
+
+// DeactivatePlayer hides the player from the board, but keeps its debt and journal,
+// so that leaving and joining again does not clear the debt.
+func (s service) DeactivatePlayer(ctx context.Context, discordId string, guildId string) error {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	tx, err := conn.StartTransaction(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	doesExist, err := tx.Queries().DoesPlayerExist(
+		ctx,
+		sqlc.DoesPlayerExistParams{DiscordID: discordId, GuildID: guildId},
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	if !doesExist {
+		return fmt.Errorf("%w: %s@%s", ErrPlayerDoesNotExist, discordId, guildId)
+	}
+
+	id, err := tx.Queries().GetIdOfPlayer(
+		ctx, sqlc.GetIdOfPlayerParams{
+			DiscordID: discordId,
+			GuildID:   guildId,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	err = tx.Queries().SetPlayerActive(
+		ctx, sqlc.SetPlayerActiveParams{
+			ID:     id,
+			Active: false,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
 	err = tx.Commit(ctx)
 	if err != nil {
 		return fmt.Errorf("%w: %s", ErrDatabase, err)
@@ -99,6 +301,37 @@ func (s service) AddPlayer(
 	return nil
 }
 
+// UpdatePlayerName stores the current names of the player. It reports whether the names changed,
+// users that are not registered in the guild are ignored.
+func (s service) UpdatePlayerName(
+	ctx context.Context,
+	discordId string,
+	guildId string,
+	discordName string,
+	nick string,
+) (bool, error) {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	updated, err := conn.Queries().UpdatePlayerName(
+		ctx, sqlc.UpdatePlayerNameParams{
+			DiscordID:   discordId,
+			GuildID:     guildId,
+			DiscordName: discordName,
+			Name:        nick,
+		},
+	)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return updated > 0, nil
+}
+
+// DeletePlayer removes the player together with its debt and journal.
 func (s service) DeletePlayer(ctx context.Context, discordId string, guildId string) error {
 	conn, err := s.db.Connect(ctx)
 	if err != nil {
@@ -145,6 +378,24 @@ func (s service) DeletePlayer(ctx context.Context, discordId string, guildId str
 	return nil
 }
 
+// GetAllPlayersWithInactive returns the active and the inactive players of the guild, inactive players
+// keep their debt until they are deleted.
+func (s service) GetAllPlayersWithInactive(ctx context.Context, guildId string) ([]models.Player, error) {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	allPlayers, err := conn.Queries().GetAllPlayersWithInactive(ctx, guildId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return fromdb.FromAllPlayersWithInactive(allPlayers), nil
+}
+
+// GetAllPlayers returns the active players of the guild.
 func (s service) GetAllPlayers(ctx context.Context, guildId string) ([]models.Player, error) {
 	conn, err := s.db.Connect(ctx)
 	if err != nil {
@@ -160,6 +411,7 @@ func (s service) GetAllPlayers(ctx context.Context, guildId string) ([]models.Pl
 	return fromdb.FromAllPlayers(allPlayers), nil
 }
 
+// GetPlayer returns the player of the guild, active or not, with the debt and the sum of the pending payments.
 func (s service) GetPlayer(ctx context.Context, discordId string, guildId string) (*models.Player, error) {
 	conn, err := s.db.Connect(ctx)
 	if err != nil {
@@ -173,15 +425,31 @@ func (s service) GetPlayer(ctx context.Context, discordId string, guildId string
 			GuildID:   guildId,
 		},
 	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s@%s", ErrPlayerDoesNotExist, discordId, guildId)
+	} else if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	pending, err := conn.Queries().GetPendingPaymentSum(ctx, player.Player.ID)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
 	}
 
 	res := fromdb.FromPlayerWithDebt(player)
+	res.PendingPayment = pending
 	return &res, nil
 }
 
-func (s service) AddDebt(ctx context.Context, discordId string, guildId string, amount int64) error {
+// AddDebt increments the debt within the database, so that concurrent changes to the
+// debt of the same player are not lost.
+func (s service) AddDebt(
+	ctx context.Context,
+	discordId string,
+	guildId string,
+	amount int64,
+	category string,
+	description string,
+) error {
 	conn, err := s.db.Connect(ctx)
 	if err != nil {
 		return fmt.Errorf("%w: %s", ErrDatabase, err)
@@ -195,8 +463,8 @@ func (s service) AddDebt(ctx context.Context, discordId string, guildId string,
 
 	queries := tx.Queries()
 
-	player, err := queries.GetPlayer(
-		ctx, sqlc.GetPlayerParams{
+	id, err := queries.GetIdOfPlayer(
+		ctx, sqlc.GetIdOfPlayerParams{
 			DiscordID: discordId,
 			GuildID:   guildId,
 		},
@@ -204,14 +472,27 @@ func (s service) AddDebt(ctx context.Context, discordId string, guildId string,
 	if err != nil {
 		return fmt.Errorf("%w: %s", ErrDatabase, err)
 	}
-	currentPlayer := fromdb.FromPlayerWithDebt(player)
 
-	newAmount := currentPlayer.Debt.Amount + amount
+	if amount == 0 {
+		return nil
+	}
 
-	err = queries.SetDebt(
-		ctx, sqlc.SetDebtParams{
-			Amount: newAmount,
-			UserID: currentPlayer.Id,
+	_, err = queries.AddToDebt(
+		ctx, sqlc.AddToDebtParams{
+			Amount: amount,
+			UserID: id,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	_, err = queries.AddJournalEntry(
+		ctx, sqlc.AddJournalEntryParams{
+			Amount:      amount,
+			Description: description,
+			UserID:      id,
+			Category:    category,
 		},
 	)
 	if err != nil {
@@ -226,7 +507,7 @@ func (s service) AddDebt(ctx context.Context, discordId string, guildId string,
 	return nil
 }
 
-func (s service) ResetDebt(ctx context.Context, discordId string, guildId string) error {
+func (s service) ResetDebt(ctx context.Context, discordId string, guildId string, description string) error {
 	conn, err := s.db.Connect(ctx)
 	if err != nil {
 		return fmt.Errorf("%w: %s", ErrDatabase, err)
@@ -240,8 +521,8 @@ func (s service) ResetDebt(ctx context.Context, discordId string, guildId string
 
 	queries := tx.Queries()
 
-	player, err := queries.GetPlayer(
-		ctx, sqlc.GetPlayerParams{
+	player, err := queries.GetPlayerForUpdate(
+		ctx, sqlc.GetPlayerForUpdateParams{
 			DiscordID: discordId,
 			GuildID:   guildId,
 		},
@@ -249,16 +530,11 @@ func (s service) ResetDebt(ctx context.Context, discordId string, guildId string
 	if err != nil {
 		return fmt.Errorf("%w: %s", ErrDatabase, err)
 	}
-	currentPlayer := fromdb.FromPlayerWithDebt(player)
+	currentPlayer := fromdb.FromLockedPlayerWithDebt(player)
 
-	err = queries.SetDebt(
-		ctx, sqlc.SetDebtParams{
-			Amount: 0,
-			UserID: currentPlayer.Id,
-		},
-	)
+	err = setDebtWithJournal(ctx, queries, currentPlayer, 0, "", description)
 	if err != nil {
-		return fmt.Errorf("%w: %s", ErrDatabase, err)
+		return err
 	}
 
 	err = tx.Commit(ctx)
@@ -269,13 +545,13 @@ func (s service) ResetDebt(ctx context.Context, discordId string, guildId string
 	return nil
 }
 
-func (s service) SetBotSetup(
-	ctx context.Context,
-	guildId string,
-	channelId string,
-	registrationMessageId string,
-	debtsMessageId string,
-) error {
+// PayDebt applies a payment to the debt at once. Payments that are still pending, e.g. from before the
+// treasurer channel was unset, may be approved later, so together with them it must not exceed the debt.
+func (s service) PayDebt(ctx context.Context, discordId string, guildId string, amount int64) error {
+	if amount <= 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidAmount, amount)
+	}
+
 	conn, err := s.db.Connect(ctx)
 	if err != nil {
 		return fmt.Errorf("%w: %s", ErrDatabase, err)
@@ -287,25 +563,43 @@ func (s service) SetBotSetup(
 		return fmt.Errorf("%w: %s", ErrDatabase, err)
 	}
 
-	doesExist, err := tx.Queries().DoesBotSetupExist(ctx, guildId)
+	queries := tx.Queries()
+
+	player, err := queries.GetPlayerForUpdate(
+		ctx, sqlc.GetPlayerForUpdateParams{
+			DiscordID: discordId,
+			GuildID:   guildId,
+		},
+	)
 	if err != nil {
 		return fmt.Errorf("%w: %s", ErrDatabase, err)
 	}
+	currentPlayer := fromdb.FromLockedPlayerWithDebt(player)
 
-	if doesExist {
-		return ErrBotSetupAlreadyExists
+	pending, err := queries.GetPendingPaymentSum(ctx, currentPlayer.Id)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	if pending+amount > currentPlayer.Debt.Amount {
+		return fmt.Errorf(
+			"%w: %v + %v pending > %v",
+			ErrAmountExceedsDebt,
+			amount,
+			pending,
+			currentPlayer.Debt.Amount,
+		)
 	}
 
-	_, err = tx.Queries().PutBotSetup(
-		ctx, sqlc.PutBotSetupParams{
-			GuildID:               guildId,
-			ChannelID:             channelId,
-			DebtsMessageID:        debtsMessageId,
-			RegistrationMessageID: registrationMessageId,
-		},
+	err = setDebtWithJournal(
+		ctx,
+		queries,
+		currentPlayer,
+		currentPlayer.Debt.Amount-amount,
+		"",
+		JournalDescriptionPayment,
 	)
 	if err != nil {
-		return fmt.Errorf("%w: %s", ErrDatabase, err)
+		return err
 	}
 
 	err = tx.Commit(ctx)
@@ -316,7 +610,18 @@ func (s service) SetBotSetup(
 	return nil
 }
 
-func (s service) GetBotSetup(ctx context.Context, guildId string) (*models.BotSetup, error) {
+// RequestPayment records a payment that is applied to the debt once a treasurer approves it.
+// Together with the payments that are still pending it must not exceed the debt.
+func (s service) RequestPayment(
+	ctx context.Context,
+	discordId string,
+	guildId string,
+	amount int64,
+) (*models.PendingPayment, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAmount, amount)
+	}
+
 	conn, err := s.db.Connect(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
@@ -328,16 +633,40 @@ func (s service) GetBotSetup(ctx context.Context, guildId string) (*models.BotSe
 		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
 	}
 
-	doesExist, err := tx.Queries().DoesBotSetupExist(ctx, guildId)
+	queries := tx.Queries()
+
+	// the lock on the debt serializes the payments of a player
+	player, err := queries.GetPlayerForUpdate(
+		ctx, sqlc.GetPlayerForUpdateParams{
+			DiscordID: discordId,
+			GuildID:   guildId,
+		},
+	)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
 	}
+	currentPlayer := fromdb.FromLockedPlayerWithDebt(player)
 
-	if !doesExist {
-		return nil, ErrBotSetupDoesNotExist
+	pending, err := queries.GetPendingPaymentSum(ctx, currentPlayer.Id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	if pending+amount > currentPlayer.Debt.Amount {
+		return nil, fmt.Errorf(
+			"%w: %v + %v pending > %v",
+			ErrAmountExceedsDebt,
+			amount,
+			pending,
+			currentPlayer.Debt.Amount,
+		)
 	}
 
-	botSetup, err := tx.Queries().GetBotSetup(ctx, guildId)
+	payment, err := queries.AddPendingPayment(
+		ctx, sqlc.AddPendingPaymentParams{
+			UserID: currentPlayer.Id,
+			Amount: amount,
+		},
+	)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
 	}
@@ -347,42 +676,981 @@ func (s service) GetBotSetup(ctx context.Context, guildId string) (*models.BotSe
 		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
 	}
 
-	res := fromdb.FromBotSetup(botSetup)
-	return &res, nil
+	return &models.PendingPayment{
+		Id:         payment.ID,
+		GuildId:    guildId,
+		DiscordId:  discordId,
+		PlayerName: currentPlayer.Name,
+		Amount:     payment.Amount,
+		CreatedAt:  payment.CreatedAt.Time.Unix(),
+	}, nil
 }
 
-func (s service) GetAllBotSetups(ctx context.Context) ([]models.BotSetup, error) {
+// ApprovePayment applies the pending payment to the debt and records it in the journal. A payment that
+// exceeds the debt, because the debt was corrected since it was reported, can never be approved. It is
+// rejected instead and returned together with ErrAmountExceedsDebt.
+func (s service) ApprovePayment(
+	ctx context.Context,
+	guildId string,
+	id int32,
+	description string,
+) (*models.PendingPayment, error) {
 	conn, err := s.db.Connect(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
 	}
 	defer conn.Close(ctx)
 
-	botSetups, err := conn.Queries().GetAllBotSetups(ctx)
+	tx, err := conn.StartTransaction(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
 	}
 
-	return fromdb.FromBotSetups(botSetups), nil
+	queries := tx.Queries()
+
+	payment, err := takePendingPayment(ctx, queries, guildId, id)
+	if err != nil {
+		return nil, err
+	}
+	player, err := queries.GetPlayerForUpdate(
+		ctx, sqlc.GetPlayerForUpdateParams{
+			DiscordID: payment.DiscordId,
+			GuildID:   guildId,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	currentPlayer := fromdb.FromLockedPlayerWithDebt(player)
+
+	// the debt may have been corrected since the payment was reported
+	if payment.Amount > currentPlayer.Debt.Amount {
+		err = tx.Commit(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+		}
+		return payment, fmt.Errorf("%w: %v > %v", ErrAmountExceedsDebt, payment.Amount, currentPlayer.Debt.Amount)
+	}
+
+	err = setDebtWithJournal(
+		ctx,
+		queries,
+		currentPlayer,
+		currentPlayer.Debt.Amount-payment.Amount,
+		"",
+		description,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return payment, nil
 }
 
-func (s service) DeleteBotSetup(ctx context.Context, guildId string) error {
+// RejectPayment discards the pending payment, the debt stays as it is.
+func (s service) RejectPayment(ctx context.Context, guildId string, id int32) (*models.PendingPayment, error) {
 	conn, err := s.db.Connect(ctx)
 	if err != nil {
-		return fmt.Errorf("%w: %s", ErrDatabase, err)
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
 	}
 	defer conn.Close(ctx)
 
-	doesExist, err := conn.Queries().DoesBotSetupExist(ctx, guildId)
+	tx, err := conn.StartTransaction(ctx)
 	if err != nil {
-		return fmt.Errorf("%w: %s", ErrDatabase, err)
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
 	}
 
-	if !doesExist {
-		return ErrBotSetupDoesNotExist
+	payment, err := takePendingPayment(ctx, tx.Queries(), guildId, id)
+	if err != nil {
+		return nil, err
 	}
 
-	err = conn.Queries().DeleteBotSetup(ctx, guildId)
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return payment, nil
+}
+
+// takePendingPayment removes the pending payment, so that a payment is approved or rejected only once.
+func takePendingPayment(
+	ctx context.Context,
+	queries db.Queries,
+	guildId string,
+	id int32,
+) (*models.PendingPayment, error) {
+	row, err := queries.GetPendingPaymentForUpdate(
+		ctx, sqlc.GetPendingPaymentForUpdateParams{
+			ID:      id,
+			GuildID: guildId,
+		},
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d@%s", ErrPendingPaymentDoesNotExist, id, guildId)
+	} else if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	err = queries.DeletePendingPayment(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	payment := fromdb.FromPendingPayment(row)
+	return &payment, nil
+}
+
+func (s service) CorrectDebt(
+	ctx context.Context,
+	discordId string,
+	guildId string,
+	amount int64,
+	description string,
+) error {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	tx, err := conn.StartTransaction(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	queries := tx.Queries()
+
+	player, err := queries.GetPlayerForUpdate(
+		ctx, sqlc.GetPlayerForUpdateParams{
+			DiscordID: discordId,
+			GuildID:   guildId,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	currentPlayer := fromdb.FromLockedPlayerWithDebt(player)
+
+	err = setDebtWithJournal(ctx, queries, currentPlayer, amount, "", description)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return nil
+}
+
+// setDebtWithJournal sets the debt of the given player to newAmount and records the
+// difference to the current amount in the journal. It has to be called within a transaction
+// that locked the debt of the player, so that the debt and the journal never diverge.
+func setDebtWithJournal(
+	ctx context.Context,
+	queries db.Queries,
+	player models.Player,
+	newAmount int64,
+	category string,
+	description string,
+) error {
+	delta := newAmount - player.Debt.Amount
+	if delta == 0 {
+		return nil
+	}
+
+	err := queries.SetDebt(
+		ctx, sqlc.SetDebtParams{
+			Amount: newAmount,
+			UserID: player.Id,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	_, err = queries.AddJournalEntry(
+		ctx, sqlc.AddJournalEntryParams{
+			Amount:      delta,
+			Description: description,
+			UserID:      player.Id,
+			Category:    category,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return nil
+}
+
+func (s service) GetHistory(
+	ctx context.Context,
+	guildId string,
+	discordId string,
+	category string,
+	limit int32,
+	offset int32,
+) ([]models.DebtJournalEntry, error) {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	if discordId == "" {
+		entries, err := conn.Queries().GetJournalEntriesOfGuild(
+			ctx, sqlc.GetJournalEntriesOfGuildParams{
+				GuildID:  guildId,
+				Category: category,
+				Lim:      limit,
+				Off:      offset,
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+		}
+		return fromdb.FromJournalEntriesOfGuild(guildId, entries), nil
+	}
+
+	entries, err := conn.Queries().GetJournalEntriesOfPlayer(
+		ctx, sqlc.GetJournalEntriesOfPlayerParams{
+			GuildID:   guildId,
+			DiscordID: discordId,
+			Category:  category,
+			Lim:       limit,
+			Off:       offset,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	return fromdb.FromJournalEntriesOfPlayer(guildId, entries), nil
+}
+
+// GetJournal returns the journal entries of all players of the guild from the given time up to but
+// not including the given end, the oldest first.
+func (s service) GetJournal(
+	ctx context.Context,
+	guildId string,
+	from time.Time,
+	to time.Time,
+) ([]models.DebtJournalEntry, error) {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	entries, err := conn.Queries().GetJournalEntriesOfGuildBetween(
+		ctx, sqlc.GetJournalEntriesOfGuildBetweenParams{
+			GuildID:  guildId,
+			DateFrom: pgtype.Timestamp{Time: from.UTC(), Valid: true},
+			DateTo:   pgtype.Timestamp{Time: to.UTC(), Valid: true},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return fromdb.FromJournalEntriesOfGuildBetween(guildId, entries), nil
+}
+
+func (s service) SetBotSetup(
+	ctx context.Context,
+	guildId string,
+	channelId string,
+	registrationMessageId string,
+	debtsMessageId string,
+) error {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	tx, err := conn.StartTransaction(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	doesExist, err := tx.Queries().DoesBotSetupExist(ctx, guildId)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	if doesExist {
+		return ErrBotSetupAlreadyExists
+	}
+
+	_, err = tx.Queries().PutBotSetup(
+		ctx, sqlc.PutBotSetupParams{
+			GuildID:               guildId,
+			ChannelID:             channelId,
+			DebtsMessageID:        debtsMessageId,
+			RegistrationMessageID: registrationMessageId,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return nil
+}
+
+// UpdateBotSetupMessages replaces the messages of an existing bot setup, e.g. after they have been deleted.
+func (s service) UpdateBotSetupMessages(
+	ctx context.Context,
+	guildId string,
+	registrationMessageId string,
+	debtsMessageId string,
+) error {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	doesExist, err := conn.Queries().DoesBotSetupExist(ctx, guildId)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	if !doesExist {
+		return ErrBotSetupDoesNotExist
+	}
+
+	err = conn.Queries().UpdateBotSetupMessages(
+		ctx, sqlc.UpdateBotSetupMessagesParams{
+			GuildID:               guildId,
+			RegistrationMessageID: registrationMessageId,
+			DebtsMessageID:        debtsMessageId,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return nil
+}
+
+// SetAwaitingReactions marks whether the registration message of the guild still lacks the reactions
+// of some active players, see models.BotSetup. Marking it again restarts the grace period.
+func (s service) SetAwaitingReactions(ctx context.Context, guildId string, awaiting bool) error {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	err = conn.Queries().SetBotSetupAwaitingReactions(ctx, awaitingReactionsParams(guildId, awaiting))
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return nil
+}
+
+func awaitingReactionsParams(guildId string, awaiting bool) sqlc.SetBotSetupAwaitingReactionsParams {
+	params := sqlc.SetBotSetupAwaitingReactionsParams{GuildID: guildId}
+	if awaiting {
+		params.AwaitingReactionsUntil = pgtype.Timestamp{
+			Time:  time.Now().Add(AwaitingReactionsGracePeriod).UTC(),
+			Valid: true,
+		}
+	}
+	return params
+}
+
+func (s service) GetBotSetup(ctx context.Context, guildId string) (*models.BotSetup, error) {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	tx, err := conn.StartTransaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	doesExist, err := tx.Queries().DoesBotSetupExist(ctx, guildId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	if !doesExist {
+		return nil, ErrBotSetupDoesNotExist
+	}
+
+	botSetup, err := tx.Queries().GetBotSetup(ctx, guildId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	res := fromdb.FromBotSetup(botSetup)
+	return &res, nil
+}
+
+func (s service) GetAllBotSetups(ctx context.Context) ([]models.BotSetup, error) {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	botSetups, err := conn.Queries().GetAllBotSetups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return fromdb.FromBotSetups(botSetups), nil
+}
+
+func (s service) DeleteBotSetup(ctx context.Context, guildId string) error {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	doesExist, err := conn.Queries().DoesBotSetupExist(ctx, guildId)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	if !doesExist {
+		return ErrBotSetupDoesNotExist
+	}
+
+	err = conn.Queries().DeleteBotSetup(ctx, guildId)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return nil
+}
+
+// GetGuildSettings returns the settings of the given guild, or the default settings
+// if the guild has not configured anything yet.
+func (s service) GetGuildSettings(ctx context.Context, guildId string) (*models.GuildSettings, error) {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	doExist, err := conn.Queries().DoGuildSettingsExist(ctx, guildId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	if !doExist {
+		res := models.DefaultGuildSettings(guildId)
+		return &res, nil
+	}
+
+	guildSettings, err := conn.Queries().GetGuildSettings(ctx, guildId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	res := fromdb.FromGuildSettings(guildSettings)
+	return &res, nil
+}
+
+func (s service) UpdateGuildSettings(ctx context.Context, settings models.GuildSettings) error {
+	err := validateGuildSettings(settings)
+	if err != nil {
+		return err
+	}
+
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	_, err = conn.Queries().PutGuildSettings(
+		ctx, sqlc.PutGuildSettingsParams{
+			GuildID:            settings.GuildId,
+			PenaltyAmount:      settings.PenaltyAmount,
+			AdminRoleID:        settings.AdminRoleId,
+			RegistrationEmoji:  settings.RegistrationEmoji,
+			BoardTitle:         settings.BoardTitle,
+			BoardColor:         settings.BoardColor,
+			SortOrder:          string(settings.SortOrder),
+			Locale:             settings.Locale,
+			TreasurerChannelID: settings.TreasurerChannelId,
+			ReminderWeekday:    int32(settings.ReminderWeekday),
+			ReminderTime:       settings.ReminderTime,
+			ReminderThreshold:  settings.ReminderThreshold,
+			Timezone:           settings.Timezone,
+			SummaryWeekday:     int32(settings.SummaryWeekday),
+			SummaryTime:        settings.SummaryTime,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return nil
+}
+
+func validateGuildSettings(settings models.GuildSettings) error {
+	switch {
+	case settings.PenaltyAmount <= 0:
+		return fmt.Errorf("%w: penalty amount must be positive", ErrInvalidGuildSettings)
+	case settings.RegistrationEmoji == "":
+		return fmt.Errorf("%w: registration emoji must not be empty", ErrInvalidGuildSettings)
+	case settings.BoardTitle != "" && strings.TrimSpace(settings.BoardTitle) == "" ||
+		utf8.RuneCountInString(settings.BoardTitle) > MaxBoardTitleLength:
+		return fmt.Errorf(
+			"%w: board title must not be blank or longer than %d characters",
+			ErrInvalidGuildSettings,
+			MaxBoardTitleLength,
+		)
+	case settings.BoardColor < 0 || settings.BoardColor > MaxBoardColor:
+		return fmt.Errorf("%w: board color must be a rgb color", ErrInvalidGuildSettings)
+	case settings.SortOrder != models.SortOrderName && settings.SortOrder != models.SortOrderDebt:
+		return fmt.Errorf("%w: unknown sort order %s", ErrInvalidGuildSettings, settings.SortOrder)
+	case settings.Locale != "" && !isLocale(settings.Locale):
+		return fmt.Errorf("%w: unknown locale %s", ErrInvalidGuildSettings, settings.Locale)
+	case settings.ReminderWeekday != models.ScheduleOff &&
+		(settings.ReminderWeekday < time.Sunday || settings.ReminderWeekday > time.Saturday):
+		return fmt.Errorf("%w: unknown reminder weekday %d", ErrInvalidGuildSettings, settings.ReminderWeekday)
+	case settings.ReminderTime < 0 || settings.ReminderTime >= MinutesPerDay:
+		return fmt.Errorf("%w: reminder time must be a minute of the day", ErrInvalidGuildSettings)
+	case settings.ReminderThreshold < 0:
+		return fmt.Errorf("%w: reminder threshold must not be negative", ErrInvalidGuildSettings)
+	case settings.SummaryWeekday != models.ScheduleOff &&
+		(settings.SummaryWeekday < time.Sunday || settings.SummaryWeekday > time.Saturday):
+		return fmt.Errorf("%w: unknown summary weekday %d", ErrInvalidGuildSettings, settings.SummaryWeekday)
+	case settings.SummaryTime < 0 || settings.SummaryTime >= MinutesPerDay:
+		return fmt.Errorf("%w: summary time must be a minute of the day", ErrInvalidGuildSettings)
+	case !isTimezone(settings.Timezone):
+		return fmt.Errorf("%w: unknown timezone %s", ErrInvalidGuildSettings, settings.Timezone)
+	}
+	return nil
+}
+
+func isLocale(code string) bool {
+	_, ok := i18n.Parse(code)
+	return ok
+}
+
+// isTimezone accepts the IANA names of timezones, but not the local timezone of the host.
+func isTimezone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+func (s service) GetPenaltyCategories(ctx context.Context, guildId string) ([]models.PenaltyCategory, error) {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	categories, err := conn.Queries().GetPenaltyCategories(ctx, guildId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return fromdb.FromPenaltyCategories(categories), nil
+}
+
+func (s service) GetPenaltyCategory(ctx context.Context, guildId string, id int32) (*models.PenaltyCategory, error) {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	category, err := conn.Queries().GetPenaltyCategory(
+		ctx, sqlc.GetPenaltyCategoryParams{
+			GuildID: guildId,
+			ID:      id,
+		},
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d@%s", ErrPenaltyCategoryDoesNotExist, id, guildId)
+	} else if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	res := fromdb.FromPenaltyCategory(category)
+	return &res, nil
+}
+
+func (s service) AddPenaltyCategory(ctx context.Context, guildId string, name string, amount int64) error {
+	if name == "" || utf8.RuneCountInString(name) > MaxPenaltyCategoryNameLength || strings.Contains(name, "||") {
+		return fmt.Errorf("%w: %q", ErrInvalidPenaltyCategoryName, name)
+	}
+	if amount <= 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidAmount, amount)
+	}
+
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	tx, err := conn.StartTransaction(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	doesExist, err := tx.Queries().DoesPenaltyCategoryExist(
+		ctx, sqlc.DoesPenaltyCategoryExistParams{
+			GuildID: guildId,
+			Name:    name,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	if doesExist {
+		return fmt.Errorf("%w: %s@%s", ErrPenaltyCategoryAlreadyExists, name, guildId)
+	}
+
+	count, err := tx.Queries().NumberOfPenaltyCategories(ctx, guildId)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	if count >= MaxPenaltyCategories {
+		return fmt.Errorf("%w: %v@%s", ErrTooManyPenaltyCategories, count, guildId)
+	}
+
+	_, err = tx.Queries().AddPenaltyCategory(
+		ctx, sqlc.AddPenaltyCategoryParams{
+			GuildID: guildId,
+			Name:    name,
+			Amount:  amount,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return nil
+}
+
+func (s service) DeletePenaltyCategory(ctx context.Context, guildId string, name string) error {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	doesExist, err := conn.Queries().DoesPenaltyCategoryExist(
+		ctx, sqlc.DoesPenaltyCategoryExistParams{
+			GuildID: guildId,
+			Name:    name,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	if !doesExist {
+		return fmt.Errorf("%w: %s@%s", ErrPenaltyCategoryDoesNotExist, name, guildId)
+	}
+
+	err = conn.Queries().DeletePenaltyCategory(
+		ctx, sqlc.DeletePenaltyCategoryParams{
+			GuildID: guildId,
+			Name:    name,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return nil
+}
+
+// GetGuildSettingsWithReminders returns the settings of the guilds that remind their debtors on some weekday.
+func (s service) GetGuildSettingsWithReminders(ctx context.Context) ([]models.GuildSettings, error) {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	guildSettings, err := conn.Queries().GetGuildSettingsWithReminders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	res := make([]models.GuildSettings, len(guildSettings))
+	for i, settings := range guildSettings {
+		res[i] = fromdb.FromGuildSettings(settings)
+	}
+	return res, nil
+}
+
+// ClaimDebtReminders returns the players of the guild whose debt exceeds the threshold and who were not
+// reminded for the given due time yet, and records that they are reminded. The reminders are recorded
+// before they are sent, so a player is reminded at most once even if sending fails or the bot restarts.
+func (s service) ClaimDebtReminders(
+	ctx context.Context,
+	guildId string,
+	threshold int64,
+	dueAt time.Time,
+) ([]models.Player, error) {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	tx, err := conn.StartTransaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	queries := tx.Queries()
+	due := pgtype.Timestamp{Time: dueAt.UTC(), Valid: true}
+
+	// only the reminders of the latest due time are needed to not send them twice
+	err = queries.DeleteRemindersBefore(ctx, sqlc.DeleteRemindersBeforeParams{GuildID: guildId, DueAt: due})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	rows, err := queries.GetPlayersToRemind(
+		ctx, sqlc.GetPlayersToRemindParams{
+			GuildID:   guildId,
+			Threshold: threshold,
+			DueAt:     due,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	players := make([]models.Player, 0, len(rows))
+	for _, row := range rows {
+		added, err := queries.AddReminder(ctx, sqlc.AddReminderParams{UserID: row.Player.ID, DueAt: due})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+		}
+		if added > 0 {
+			players = append(players, fromdb.FromPlayerWithDebt(sqlc.GetPlayerRow(row)))
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return players, nil
+}
+
+// SetRemindersEnabled lets a player opt out of debt reminders and back in.
+func (s service) SetRemindersEnabled(ctx context.Context, discordId string, guildId string, enabled bool) error {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	doesExist, err := conn.Queries().DoesPlayerExist(
+		ctx,
+		sqlc.DoesPlayerExistParams{DiscordID: discordId, GuildID: guildId},
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	if !doesExist {
+		return fmt.Errorf("%w: %s@%s", ErrPlayerDoesNotExist, discordId, guildId)
+	}
+
+	id, err := conn.Queries().GetIdOfPlayer(
+		ctx, sqlc.GetIdOfPlayerParams{
+			DiscordID: discordId,
+			GuildID:   guildId,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	if enabled {
+		err = conn.Queries().DeleteReminderOptOut(ctx, id)
+	} else {
+		err = conn.Queries().AddReminderOptOut(ctx, id)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return nil
+}
+
+func (s service) GetGuildSettingsWithSummaries(ctx context.Context) ([]models.GuildSettings, error) {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	guildSettings, err := conn.Queries().GetGuildSettingsWithSummaries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	res := make([]models.GuildSettings, len(guildSettings))
+	for i, settings := range guildSettings {
+		res[i] = fromdb.FromGuildSettings(settings)
+	}
+	return res, nil
+}
+
+// ClaimWeeklySummary records that the weekly summary of the given due time is posted and reports whether
+// it was not recorded before. Like reminders, a summary is posted at most once.
+func (s service) ClaimWeeklySummary(ctx context.Context, guildId string, dueAt time.Time) (bool, error) {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	tx, err := conn.StartTransaction(ctx)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	due := pgtype.Timestamp{Time: dueAt.UTC(), Valid: true}
+	err = tx.Queries().DeleteWeeklySummariesBefore(
+		ctx, sqlc.DeleteWeeklySummariesBeforeParams{
+			GuildID: guildId,
+			DueAt:   due,
+		},
+	)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	added, err := tx.Queries().AddWeeklySummary(ctx, sqlc.AddWeeklySummaryParams{GuildID: guildId, DueAt: due})
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return added > 0, nil
+}
+
+// MarkGuildOrphaned remembers that the bot left the guild, its data is kept until it is purged.
+func (s service) MarkGuildOrphaned(ctx context.Context, guildId string) error {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	err = conn.Queries().MarkGuildOrphaned(ctx, guildId)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return nil
+}
+
+// UnmarkGuildOrphaned keeps the data of a guild that added the bot again.
+func (s service) UnmarkGuildOrphaned(ctx context.Context, guildId string) error {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	err = conn.Queries().UnmarkGuildOrphaned(ctx, guildId)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return nil
+}
+
+// GetOrphanedGuilds returns the guilds that have been orphaned before the given time. The orphaning
+// is stored in UTC, so the time is compared in UTC as well.
+func (s service) GetOrphanedGuilds(ctx context.Context, orphanedBefore time.Time) ([]string, error) {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	guildIds, err := conn.Queries().GetOrphanedGuilds(ctx, pgtype.Timestamp{Time: orphanedBefore.UTC(), Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	return guildIds, nil
+}
+
+// PurgeGuild removes everything that is stored about the guild: players with their debts and journal,
+// the bot setup, the settings and the penalty categories.
+func (s service) PurgeGuild(ctx context.Context, guildId string) error {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	tx, err := conn.StartTransaction(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	purges := []func(ctx context.Context, guildId string) error{
+		tx.Queries().DeletePlayersOfGuild,
+		tx.Queries().DeleteBotSetup,
+		tx.Queries().DeleteGuildSettings,
+		tx.Queries().DeletePenaltyCategoriesOfGuild,
+		tx.Queries().UnmarkGuildOrphaned,
+		tx.Queries().DeleteWeeklySummariesOfGuild,
+		func(ctx context.Context, guildId string) error {
+			_, err := tx.Queries().DeleteApiToken(ctx, guildId)
+			return err
+		},
+	}
+	for _, purge := range purges {
+		err = purge(ctx, guildId)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrDatabase, err)
+		}
+	}
+
+	err = tx.Commit(ctx)
 	if err != nil {
 		return fmt.Errorf("%w: %s", ErrDatabase, err)
 	}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"context"
+	"slash10k/pkg/models"
+	"slash10k/pkg/utils"
+	"time"
+)
+
+const (
+	// GuildSettingsCacheTtl bounds how long settings changed outside of this service are stale.
+	GuildSettingsCacheTtl  = 10 * time.Minute
+	MaxCachedGuildSettings = 1000
+)
+
+// cachedGuildSettings keeps the settings of recently active guilds in memory, they are read
+// for every render of the board and every reaction on a registration message.
+type cachedGuildSettings struct {
+	Service
+	settings *utils.ExpiringMap[string, models.GuildSettings]
+}
+
+var _ Service = (*cachedGuildSettings)(nil)
+
+// WithCachedGuildSettings wraps the service so that guild settings are only read from the database
+// when they are not cached yet. Changes through the returned service update the cache.
+func WithCachedGuildSettings(service Service) Service {
+	return &cachedGuildSettings{
+		Service:  service,
+		settings: utils.NewExpiringMap[string, models.GuildSettings](GuildSettingsCacheTtl, MaxCachedGuildSettings),
+	}
+}
+
+func (c *cachedGuildSettings) GetGuildSettings(ctx context.Context, guildId string) (*models.GuildSettings, error) {
+	if settings, ok := c.settings.Load(guildId); ok {
+		return &settings, nil
+	}
+	settings, err := c.Service.GetGuildSettings(ctx, guildId)
+	if err != nil {
+		return nil, err
+	}
+	c.settings.Store(guildId, *settings)
+	return settings, nil
+}
+
+func (c *cachedGuildSettings) UpdateGuildSettings(ctx context.Context, settings models.GuildSettings) error {
+	c.settings.LoadAndRemove(settings.GuildId)
+	err := c.Service.UpdateGuildSettings(ctx, settings)
+	if err != nil {
+		return err
+	}
+	c.settings.Store(settings.GuildId, settings)
+	return nil
+}
+
+func (c *cachedGuildSettings) PurgeGuild(ctx context.Context, guildId string) error {
+	c.settings.LoadAndRemove(guildId)
+	return c.Service.PurgeGuild(ctx, guildId)
+}
@@ -0,0 +1,41 @@
+package domain_test
+
+import (
+	"context"
+	"go.uber.org/mock/gomock"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/models"
+	"slash10k/pkg/testutil"
+	"testing"
+)
+
+func Test_CachedGuildSettings(t *testing.T) {
+	ctx := context.Background()
+	c := gomock.NewController(t)
+	mockDb, mockQueries := testutil.QueriesMock(c)
+	mockQueries.EXPECT().
+		DoGuildSettingsExist(gomock.Any(), testutil.TestGuildIdString()).
+		Times(1).
+		Return(false, nil)
+	mockQueries.EXPECT().
+		PutGuildSettings(gomock.Any(), gomock.Any()).
+		Times(1)
+	service := domain.WithCachedGuildSettings(domain.NewSlashTenK(mockDb))
+
+	settings, err := service.GetGuildSettings(ctx, testutil.TestGuildIdString())
+	testutil.WithoutError(t, settings, err)
+	settings.PenaltyAmount = 5000
+	cached, err := service.GetGuildSettings(ctx, testutil.TestGuildIdString())
+	testutil.WithoutError(t, cached, err)
+	if cached.PenaltyAmount != models.DefaultPenaltyAmount {
+		t.Fatalf("Expected changes to a returned copy to not affect the cache, got %v", cached.PenaltyAmount)
+	}
+
+	err = service.UpdateGuildSettings(ctx, *settings)
+	testutil.WithoutError(t, nil, err)
+	updated, err := service.GetGuildSettings(ctx, testutil.TestGuildIdString())
+	testutil.WithoutError(t, updated, err)
+	if updated.PenaltyAmount != 5000 {
+		t.Fatalf("Expected the updated settings to be cached, got %v", updated.PenaltyAmount)
+	}
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	"slash10k/pkg/convert/fromdb"
+	"slash10k/pkg/models"
+	sqlc "slash10k/sql/gen"
+	"strings"
+	"time"
+)
+
+const (
+	SummaryPeriod = 7 * 24 * time.Hour
+	// MaxSummaryDebtors is the number of biggest debtors a summary shows.
+	MaxSummaryDebtors = 3
+)
+
+// GetWeeklySummary summarizes the journal of the guild over the seven days before end. The debts at
+// the end of the week are the current debts without the journal entries that were written since.
+func (s service) GetWeeklySummary(ctx context.Context, guildId string, end time.Time) (*models.WeeklySummary, error) {
+	conn, err := s.db.Connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	defer conn.Close(ctx)
+
+	players, err := conn.Queries().GetAllPlayers(ctx, guildId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+	to := time.Now()
+	if end.After(to) {
+		to = end
+	}
+	entries, err := conn.Queries().GetJournalEntriesOfGuildBetween(
+		ctx, sqlc.GetJournalEntriesOfGuildBetweenParams{
+			GuildID:  guildId,
+			DateFrom: pgtype.Timestamp{Time: end.Add(-2 * SummaryPeriod).UTC(), Valid: true},
+			// the end is exclusive, entries of this very moment are included anyway
+			DateTo: pgtype.Timestamp{Time: to.Add(time.Second).UTC(), Valid: true},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDatabase, err)
+	}
+
+	summary := summarizeWeek(
+		guildId,
+		fromdb.FromAllPlayers(players),
+		fromdb.FromJournalEntriesOfGuildBetween(guildId, entries),
+		end,
+	)
+	return &summary, nil
+}
+
+// summarizeWeek needs the current debts of the players and the journal from two weeks before the end until now.
+func summarizeWeek(
+	guildId string,
+	players models.Players,
+	journal []models.DebtJournalEntry,
+	end time.Time,
+) models.WeeklySummary {
+	start := end.Add(-SummaryPeriod)
+	summary := models.WeeklySummary{
+		GuildId: guildId,
+		Start:   start.Unix(),
+		End:     end.Unix(),
+	}
+
+	changedSince := make(map[int32]int64)
+	for _, entry := range journal {
+		switch {
+		case entry.Date >= summary.End:
+			changedSince[entry.UserId] += entry.Amount
+		case entry.Date >= summary.Start:
+			summary.NetChange += entry.Amount
+			switch {
+			case isPenalty(entry):
+				summary.Penalties += entry.Amount
+				summary.PenaltyCount++
+			case isPayment(entry):
+				summary.Payments -= entry.Amount
+				summary.PaymentCount++
+			}
+		case entry.Date >= start.Add(-SummaryPeriod).Unix():
+			summary.PreviousNetChange += entry.Amount
+		}
+	}
+
+	debtors := make(models.Players, 0, len(players))
+	for _, player := range players {
+		player.Debt.Amount -= changedSince[player.Id]
+		if player.Debt.Amount > 0 {
+			debtors = append(debtors, player)
+		} else {
+			summary.DebtFree = append(summary.DebtFree, player)
+		}
+	}
+	debtors.SortByDebt()
+	summary.BiggestDebtors = debtors[:min(len(debtors), MaxSummaryDebtors)]
+	summary.DebtFree.SortByName()
+
+	return summary
+}
+
+// isPenalty reports whether the journal entry was written for a penalty, with or without category.
+func isPenalty(entry models.DebtJournalEntry) bool {
+	return entry.Category != "" ||
+		strings.HasPrefix(entry.Description, strings.TrimSuffix(JournalDescriptionPenalty, "%s"))
+}
+
+// isPayment reports whether the journal entry was written for a payment, directly or approved by a treasurer.
+func isPayment(entry models.DebtJournalEntry) bool {
+	return entry.Description == JournalDescriptionPayment ||
+		strings.HasPrefix(entry.Description, strings.TrimSuffix(JournalDescriptionApprovedPayment, "%s"))
+}
@@ -0,0 +1,85 @@
+package domain_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/mock/gomock"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/testutil"
+	sqlc "slash10k/sql/gen"
+	"testing"
+	"time"
+)
+
+func Test_GetWeeklySummary(t *testing.T) {
+	end := time.Now().Add(-time.Hour).Truncate(time.Second)
+	player := func(id int32, name string, debt int64) sqlc.GetAllPlayersRow {
+		return sqlc.GetAllPlayersRow{
+			Player: sqlc.Player{ID: id, DiscordID: name, Name: name, GuildID: testutil.TestGuildIdString()},
+			Debt:   sqlc.Debt{UserID: id, Amount: debt},
+		}
+	}
+	entry := func(userId int32, amount int64, date time.Time, description string) sqlc.GetJournalEntriesOfGuildBetweenRow {
+		return sqlc.GetJournalEntriesOfGuildBetweenRow{
+			DebtJournal: sqlc.DebtJournal{
+				UserID:      userId,
+				Amount:      amount,
+				Date:        pgtype.Timestamp{Time: date, Valid: true},
+				Description: description,
+			},
+		}
+	}
+	penalty := fmt.Sprintf(domain.JournalDescriptionPenalty, "torfstack")
+	day := 24 * time.Hour
+
+	c := gomock.NewController(t)
+	mockDb, mockQueries := testutil.QueriesMock(c)
+	mockQueries.EXPECT().
+		GetAllPlayers(gomock.Any(), testutil.TestGuildIdString()).
+		Return(
+			[]sqlc.GetAllPlayersRow{
+				player(1, "torfstack", 30000),
+				player(2, "neruh", 0),
+				player(3, "scurvy", 10000),
+				player(4, "bob", 5000),
+			}, nil,
+		)
+	mockQueries.EXPECT().
+		GetJournalEntriesOfGuildBetween(gomock.Any(), gomock.Any()).
+		Return(
+			[]sqlc.GetJournalEntriesOfGuildBetweenRow{
+				entry(1, 10000, end.Add(-10*day), penalty),
+				entry(1, 20000, end.Add(-3*day), penalty),
+				entry(1, 5000, end.Add(-3*day), "corrected by torfstack"),
+				entry(2, 10000, end.Add(-2*day), penalty),
+				entry(2, -10000, end.Add(-day), fmt.Sprintf(domain.JournalDescriptionApprovedPayment, "neruh")),
+				entry(3, 10000, end.Add(-day), penalty),
+				entry(3, -5000, end.Add(-day), "reset by torfstack"),
+				entry(3, -10000, end, domain.JournalDescriptionPayment),
+				entry(3, 10000, end.Add(time.Minute), penalty),
+				entry(4, 5000, end.Add(time.Minute), penalty),
+			}, nil,
+		)
+
+	summary, err := domain.NewSlashTenK(mockDb).GetWeeklySummary(context.Background(), testutil.TestGuildIdString(), end)
+	testutil.WithoutError(t, summary, err)
+	// the correction and the reset are neither penalties nor payments
+	if summary.Penalties != 40000 || summary.PenaltyCount != 3 {
+		t.Errorf("Expected 3 penalties of 40000, got %d of %d", summary.PenaltyCount, summary.Penalties)
+	}
+	if summary.Payments != 10000 || summary.PaymentCount != 1 {
+		t.Errorf("Expected 1 payment of 10000, got %d of %d", summary.PaymentCount, summary.Payments)
+	}
+	if summary.NetChange != 30000 || summary.PreviousNetChange != 10000 {
+		t.Errorf("Expected net change of 30000 after 10000, got %d after %d", summary.NetChange, summary.PreviousNetChange)
+	}
+	if len(summary.BiggestDebtors) != 2 ||
+		summary.BiggestDebtors[0].Name != "torfstack" || summary.BiggestDebtors[0].Debt.Amount != 30000 ||
+		summary.BiggestDebtors[1].Name != "scurvy" || summary.BiggestDebtors[1].Debt.Amount != 10000 {
+		t.Errorf("Expected torfstack and scurvy with their debts at the end of the week, got %v", summary.BiggestDebtors)
+	}
+	if len(summary.DebtFree) != 2 || summary.DebtFree[0].Name != "bob" || summary.DebtFree[1].Name != "neruh" {
+		t.Errorf("Expected bob and neruh to be debt-free at the end of the week, got %v", summary.DebtFree)
+	}
+}
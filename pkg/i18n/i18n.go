@@ -0,0 +1,61 @@
+package i18n
+
+import (
+	"fmt"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// Locale is a language the bot speaks.
+type Locale string
+
+const (
+	English Locale = "en"
+	German  Locale = "de"
+
+	// DefaultLocale is used for users and guilds whose language is not supported.
+	DefaultLocale = English
+)
+
+// Locales are all supported locales, in the order they are offered to users.
+var Locales = []Locale{English, German}
+
+// Key identifies a message of the catalog.
+type Key string
+
+// Parse returns the supported locale with the given code.
+func Parse(code string) (Locale, bool) {
+	for _, l := range Locales {
+		if string(l) == code {
+			return l, true
+		}
+	}
+	return "", false
+}
+
+// FromDiscord returns the supported locale for the language of a Discord client or guild,
+// regional variants like en-GB share the translation of their language.
+func FromDiscord(language discord.Language) Locale {
+	if len(language) >= 2 {
+		if l, ok := Parse(string(language[:2])); ok {
+			return l
+		}
+	}
+	return DefaultLocale
+}
+
+// T returns the message in the given locale, formatted with the arguments if there are any.
+// Messages that are missing in the locale fall back to the default locale.
+func T(locale Locale, key Key, args ...any) string {
+	translations, ok := messages[key]
+	if !ok {
+		return string(key)
+	}
+	message, ok := translations[locale]
+	if !ok {
+		message = translations[DefaultLocale]
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
@@ -0,0 +1,64 @@
+package i18n
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var verbPattern = regexp.MustCompile(`%(\[\d+])?[a-z]`)
+
+func Test_messages(t *testing.T) {
+	for key, translations := range messages {
+		for _, locale := range Locales {
+			message, ok := translations[locale]
+			if !ok || strings.TrimSpace(message) == "" {
+				t.Errorf("Expected message %s in %s", key, locale)
+				continue
+			}
+			// every locale has to consume the arguments of the default locale
+			verbs := verbPattern.FindAllString(translations[DefaultLocale], -1)
+			args := make([]any, len(verbs))
+			for i, verb := range verbs {
+				args[i] = "x"
+				if strings.HasSuffix(verb, "d") {
+					args[i] = 1
+				}
+			}
+			if len(args) == 0 {
+				continue
+			}
+			if got := T(locale, key, args...); strings.Contains(got, "%!") {
+				t.Errorf("Expected message %s in %s to use all arguments, got %q", key, locale, got)
+			}
+		}
+	}
+}
+
+func Test_T(t *testing.T) {
+	if got := T(German, PayThanks, "10k"); got != "10k bezahlt, danke!" {
+		t.Errorf("T() = %q, want 10k bezahlt, danke!", got)
+	}
+	if got := T("fr", Pay); got != "Pay" {
+		t.Errorf("T() = %q, want the message of the default locale", got)
+	}
+	if got := T(German, PenaltyPrompt, "10k", "Alice"); got != "Willst du Alice wirklich 10k geben?" {
+		t.Errorf("T() = %q, want the arguments in German word order", got)
+	}
+}
+
+func Test_FromDiscord(t *testing.T) {
+	tests := map[discord.Language]Locale{
+		discord.German:    German,
+		discord.EnglishUS: English,
+		discord.EnglishUK: English,
+		discord.French:    DefaultLocale,
+		"":                DefaultLocale,
+	}
+	for language, want := range tests {
+		if got := FromDiscord(language); got != want {
+			t.Errorf("FromDiscord(%q) = %s, want %s", language, got, want)
+		}
+	}
+}
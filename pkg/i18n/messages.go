@@ -0,0 +1,700 @@
+package i18n
+
+// Messages that are shown to users. Placeholders follow fmt, messages with arguments must use
+// the same verbs in every locale, explicit argument indexes allow a different word order.
+const (
+	BoardTitle            Key = "board_title"
+	BoardPlayers          Key = "board_players"
+	BoardPlayersContinued Key = "board_players_continued"
+	BoardMorePlayers      Key = "board_more_players"
+	SelectPlayer          Key = "select_player"
+	SelectPlayerRange     Key = "select_player_range"
+	Pay                   Key = "pay"
+	Previous              Key = "previous"
+	Next                  Key = "next"
+	Cancel                Key = "cancel"
+	Confirm               Key = "confirm"
+
+	RegistrationMessage         Key = "registration_message"
+	RestoredRegistrationMessage Key = "restored_registration_message"
+	OnboardingMessage           Key = "onboarding_message"
+	ChannelDeletedMessage       Key = "channel_deleted_message"
+
+	InvalidAmount                    Key = "invalid_amount"
+	AmountNotPositive                Key = "amount_not_positive"
+	PlayerNotRegistered              Key = "player_not_registered"
+	PlayerNotRegisteredPickSuggested Key = "player_not_registered_pick_suggested"
+	NotAllowed                       Key = "not_allowed"
+	PromptExpired                    Key = "prompt_expired"
+
+	PenaltyWhich           Key = "penalty_which"
+	PenaltyPrompt          Key = "penalty_prompt"
+	PenaltyPromptCategory  Key = "penalty_prompt_category"
+	PenaltyReason          Key = "penalty_reason"
+	PenaltyFailed          Key = "penalty_failed"
+	PenaltyBot             Key = "penalty_bot"
+	SelectPenalty          Key = "select_penalty"
+	DefaultCategory        Key = "default_category"
+	CategoryNameInvalid    Key = "category_name_invalid"
+	CategoryExists         Key = "category_exists"
+	CategoryLimit          Key = "category_limit"
+	CategoryAddFailed      Key = "category_add_failed"
+	CategoryAdded          Key = "category_added"
+	CategoryDoesNotExist   Key = "category_does_not_exist"
+	CategoryDeleted        Key = "category_deleted"
+	CategoryRemoveFailed   Key = "category_remove_failed"
+	CategoryRemoved        Key = "category_removed"
+	CategoryListFailed     Key = "category_list_failed"
+	PayAmount              Key = "pay_amount"
+	PayNotRegistered       Key = "pay_not_registered"
+	PayNoDebts             Key = "pay_no_debts"
+	PayReadAmountFailed    Key = "pay_read_amount_failed"
+	PayExceedsDebt         Key = "pay_exceeds_debt"
+	PayFailed              Key = "pay_failed"
+	PayThanks              Key = "pay_thanks"
+	PayPending             Key = "pay_pending"
+	PayReportFailed        Key = "pay_report_failed"
+	PaymentReported        Key = "payment_reported"
+	PaymentApprove         Key = "payment_approve"
+	PaymentReject          Key = "payment_reject"
+	PaymentApproved        Key = "payment_approved"
+	PaymentRejected        Key = "payment_rejected"
+	PaymentApprovedDirect  Key = "payment_approved_direct"
+	PaymentRejectedDirect  Key = "payment_rejected_direct"
+	PaymentTooHigh         Key = "payment_too_high"
+	PaymentTooHighDirect   Key = "payment_too_high_direct"
+	PaymentAlreadyHandled  Key = "payment_already_handled"
+	PaymentApproveFailed   Key = "payment_approve_failed"
+	HistoryFailed          Key = "history_failed"
+	HistoryEmpty           Key = "history_empty"
+	HistoryTitle           Key = "history_title"
+	HistoryPage            Key = "history_page"
+	PlayersEmpty           Key = "players_empty"
+	PlayersPage            Key = "players_page"
+	SetChannelFailed       Key = "set_channel_failed"
+	SetupCheckFailed       Key = "setup_check_failed"
+	SetupDeleteFailed      Key = "setup_delete_failed"
+	SetupSaveFailed        Key = "setup_save_failed"
+	SetupGetFailed         Key = "setup_get_failed"
+	RegistrationFailed     Key = "registration_failed"
+	DebtsMessageFailed     Key = "debts_message_failed"
+	ChannelSet             Key = "channel_set"
+	CorrectFailed          Key = "correct_failed"
+	Corrected              Key = "corrected"
+	PenaltyAmountFailed    Key = "penalty_amount_failed"
+	PenaltyAmountSet       Key = "penalty_amount_set"
+	DeletePlayerFailed     Key = "delete_player_failed"
+	PlayerDeleted          Key = "player_deleted"
+	AdminRoleFailed        Key = "admin_role_failed"
+	AdminRoleRemoved       Key = "admin_role_removed"
+	AdminRoleSet           Key = "admin_role_set"
+	TreasurerFailed        Key = "treasurer_failed"
+	TreasurerRemoved       Key = "treasurer_removed"
+	TreasurerSet           Key = "treasurer_set"
+	SettingsFailed         Key = "settings_failed"
+	SettingsUpdateFailed   Key = "settings_update_failed"
+	SettingsInvalid        Key = "settings_invalid"
+	SettingsDefault        Key = "settings_default"
+	SettingsEmojiChanged   Key = "settings_emoji_changed"
+	ConfigInvalidEmoji     Key = "config_invalid_emoji"
+	ConfigInvalidColor     Key = "config_invalid_color"
+	ConfigInvalidSortOrder Key = "config_invalid_sort_order"
+	ConfigInvalidLanguage  Key = "config_invalid_language"
+	ConfigTitleTooLong     Key = "config_title_too_long"
+	ConfigUnknownKey       Key = "config_unknown_key"
+	ConfigInvalidWeekday   Key = "config_invalid_weekday"
+	ConfigInvalidTime      Key = "config_invalid_time"
+	ConfigInvalidTimezone  Key = "config_invalid_timezone"
+	ConfigInvalidThreshold Key = "config_invalid_threshold"
+	ReminderMessage        Key = "reminder_message"
+	RemindersOn            Key = "reminders_on"
+	RemindersOff           Key = "reminders_off"
+	RemindersFailed        Key = "reminders_failed"
+	SummaryTitle           Key = "summary_title"
+	SummaryPenalties       Key = "summary_penalties"
+	SummaryPenaltiesValue  Key = "summary_penalties_value"
+	SummaryPayments        Key = "summary_payments"
+	SummaryPaymentsValue   Key = "summary_payments_value"
+	SummaryNetChange       Key = "summary_net_change"
+	SummaryNetChangeValue  Key = "summary_net_change_value"
+	SummaryBiggestDebtors  Key = "summary_biggest_debtors"
+	SummaryDebtFree        Key = "summary_debt_free"
+	SummaryNobody          Key = "summary_nobody"
+	SummaryFailed          Key = "summary_failed"
+	ExportReady            Key = "export_ready"
+	ExportInvalidRange     Key = "export_invalid_range"
+	ExportFailed           Key = "export_failed"
+	ImportPreview          Key = "import_preview"
+	ImportNothing          Key = "import_nothing"
+	ImportSkippedRow       Key = "import_skipped_row"
+	ImportApplied          Key = "import_applied"
+	ImportCancelled        Key = "import_cancelled"
+	ImportFailed           Key = "import_failed"
+	ImportTooLarge         Key = "import_too_large"
+	ImportTooManyRows      Key = "import_too_many_rows"
+	ImportInvalidFile      Key = "import_invalid_file"
+	ImportInvalidHeader    Key = "import_invalid_header"
+	ImportInvalidRow       Key = "import_invalid_row"
+	ImportEmpty            Key = "import_empty"
+	ImportDuplicate        Key = "import_duplicate"
+	ApiTokenCreated        Key = "api_token_created"
+	ApiTokenRevoked        Key = "api_token_revoked"
+	ApiTokenNone           Key = "api_token_none"
+	ApiTokenFailed         Key = "api_token_failed"
+)
+
+var messages = map[Key]map[Locale]string{
+	BoardTitle: {
+		English: ":moneybag: {amount} to the guild bank!",
+		German:  ":moneybag: {amount} in die Gildenbank!",
+	},
+	BoardPlayers: {
+		English: "Players",
+		German:  "Spieler",
+	},
+	BoardPlayersContinued: {
+		English: "Players (%d)",
+		German:  "Spieler (%d)",
+	},
+	BoardMorePlayers: {
+		English: "%d more players do not fit on the board, select them below",
+		German:  "%d weitere Spieler passen nicht auf die Tafel, wähle sie unten aus",
+	},
+	SelectPlayer: {
+		English: "Select a player",
+		German:  "Wähle einen Spieler",
+	},
+	SelectPlayerRange: {
+		English: "Select a player (%s – %s)",
+		German:  "Wähle einen Spieler (%s – %s)",
+	},
+	Pay: {
+		English: "Pay",
+		German:  "Bezahlen",
+	},
+	Previous: {
+		English: "Previous",
+		German:  "Zurück",
+	},
+	Next: {
+		English: "Next",
+		German:  "Weiter",
+	},
+	Cancel: {
+		English: "Cancel",
+		German:  "Abbrechen",
+	},
+	Confirm: {
+		English: "Confirm",
+		German:  "Bestätigen",
+	},
+
+	RegistrationMessage: {
+		English: "%s react to join!",
+		German:  "%s reagiere, um mitzumachen!",
+	},
+	RestoredRegistrationMessage: {
+		English: "%s react to join! The previous message was deleted, " +
+			"please react again within a week, until then you stay on the board with your debt.",
+		German: "%s reagiere, um mitzumachen! Die vorherige Nachricht wurde gelöscht, " +
+			"bitte reagiere innerhalb einer Woche erneut, bis dahin bleibst du mit deinen Schulden auf der Tafel.",
+	},
+	OnboardingMessage: {
+		English: ":moneybag: Thanks for adding slash10k! " +
+			"An admin can set up the debt board with /10kup in the channel it should be posted in.",
+		German: ":moneybag: Danke, dass ihr slash10k hinzugefügt habt! " +
+			"Ein Admin kann die Schuldentafel mit /10kup in dem Channel einrichten, in dem sie stehen soll.",
+	},
+	ChannelDeletedMessage: {
+		English: "The channel of the /10k board on %s was deleted, the debts are kept. " +
+			"Use /10kup to set up the board in another channel.",
+		German: "Der Channel der /10k-Tafel auf %s wurde gelöscht, die Schulden bleiben erhalten. " +
+			"Richte die Tafel mit /10kup in einem anderen Channel ein.",
+	},
+
+	InvalidAmount: {
+		English: "'%s' is not a valid amount, try e.g. 10k, 25000 or 1.5k",
+		German:  "'%s' ist kein gültiger Betrag, versuche z.B. 10k, 25000 oder 1,5k",
+	},
+	AmountNotPositive: {
+		English: "The amount has to be positive",
+		German:  "Der Betrag muss positiv sein",
+	},
+	PlayerNotRegistered: {
+		English: "This player is not registered",
+		German:  "Dieser Spieler ist nicht registriert",
+	},
+	PlayerNotRegisteredPickSuggested: {
+		English: "This player is not registered, pick one of the suggestions",
+		German:  "Dieser Spieler ist nicht registriert, wähle einen der Vorschläge",
+	},
+	NotAllowed: {
+		English: "You are not allowed to do this, ask an admin!",
+		German:  "Das darfst du nicht, frag einen Admin!",
+	},
+	PromptExpired: {
+		English: "This prompt expired, please select the player again.",
+		German:  "Diese Abfrage ist abgelaufen, bitte wähle den Spieler erneut.",
+	},
+
+	PenaltyWhich: {
+		English: "Which penalty should %s get?",
+		German:  "Welche Strafe soll %s bekommen?",
+	},
+	PenaltyPrompt: {
+		English: "Do you really want to add %s to %s?",
+		German:  "Willst du %[2]s wirklich %[1]s geben?",
+	},
+	PenaltyPromptCategory: {
+		English: "Do you really want to add %s (%s) to %s?",
+		German:  "Willst du %[3]s wirklich %[1]s (%[2]s) geben?",
+	},
+	PenaltyReason: {
+		English: "Reason: %s",
+		German:  "Grund: %s",
+	},
+	PenaltyFailed: {
+		English: "Could not add penalty",
+		German:  "Die Strafe konnte nicht vergeben werden",
+	},
+	PenaltyBot: {
+		English: "Bots can not be penalized",
+		German:  "Bots können keine Strafen bekommen",
+	},
+	SelectPenalty: {
+		English: "Select a penalty",
+		German:  "Wähle eine Strafe",
+	},
+	DefaultCategory: {
+		English: "Default",
+		German:  "Standard",
+	},
+	CategoryNameInvalid: {
+		English: "The name must not be empty or longer than %d characters",
+		German:  "Der Name darf nicht leer oder länger als %d Zeichen sein",
+	},
+	CategoryExists: {
+		English: "Penalty category '%s' already exists",
+		German:  "Die Kategorie '%s' gibt es schon",
+	},
+	CategoryLimit: {
+		English: "A guild can have at most %d penalty categories",
+		German:  "Eine Gilde kann höchstens %d Kategorien haben",
+	},
+	CategoryAddFailed: {
+		English: "Could not add penalty category",
+		German:  "Die Kategorie konnte nicht hinzugefügt werden",
+	},
+	CategoryAdded: {
+		English: "Added penalty category '%s' (%s)",
+		German:  "Kategorie '%s' (%s) hinzugefügt",
+	},
+	CategoryDoesNotExist: {
+		English: "Penalty category '%s' does not exist",
+		German:  "Die Kategorie '%s' gibt es nicht",
+	},
+	CategoryDeleted: {
+		English: "This penalty category no longer exists",
+		German:  "Diese Kategorie gibt es nicht mehr",
+	},
+	CategoryRemoveFailed: {
+		English: "Could not remove penalty category",
+		German:  "Die Kategorie konnte nicht entfernt werden",
+	},
+	CategoryRemoved: {
+		English: "Removed penalty category '%s'",
+		German:  "Kategorie '%s' entfernt",
+	},
+	CategoryListFailed: {
+		English: "Could not list penalty categories",
+		German:  "Die Kategorien konnten nicht angezeigt werden",
+	},
+	PayAmount: {
+		English: "Amount",
+		German:  "Betrag",
+	},
+	PayNotRegistered: {
+		English: "You are not registered, react to the registration message first",
+		German:  "Du bist nicht registriert, reagiere zuerst auf die Anmeldenachricht",
+	},
+	PayNoDebts: {
+		English: "You have no debts to pay",
+		German:  "Du hast keine Schulden",
+	},
+	PayReadAmountFailed: {
+		English: "Could not read amount",
+		German:  "Der Betrag konnte nicht gelesen werden",
+	},
+	PayExceedsDebt: {
+		English: "You cannot pay more than you owe, including the payments that still await approval",
+		German:  "Du kannst nicht mehr bezahlen, als du schuldest, einschließlich der Zahlungen, die noch auf Freigabe warten",
+	},
+	PayFailed: {
+		English: "Could not pay debt",
+		German:  "Die Zahlung hat nicht geklappt",
+	},
+	PayThanks: {
+		English: "Paid %s, thank you!",
+		German:  "%s bezahlt, danke!",
+	},
+	PayPending: {
+		English: "Your payment of %s is waiting for a treasurer to approve it",
+		German:  "Deine Zahlung von %s wartet auf die Freigabe durch einen Schatzmeister",
+	},
+	PayReportFailed: {
+		English: "Could not report the payment to the treasurers, try again later",
+		German:  "Die Zahlung konnte den Schatzmeistern nicht gemeldet werden, versuche es später noch einmal",
+	},
+	PaymentReported: {
+		English: ":hourglass: <@%s> reports a payment of %s",
+		German:  ":hourglass: <@%s> meldet eine Zahlung von %s",
+	},
+	PaymentApprove: {
+		English: "Approve",
+		German:  "Freigeben",
+	},
+	PaymentReject: {
+		English: "Reject",
+		German:  "Ablehnen",
+	},
+	PaymentApproved: {
+		English: ":white_check_mark: Payment of %s by <@%s> approved by %s",
+		German:  ":white_check_mark: Zahlung von %s durch <@%s> von %s freigegeben",
+	},
+	PaymentRejected: {
+		English: ":x: Payment of %s by <@%s> rejected by %s",
+		German:  ":x: Zahlung von %s durch <@%s> von %s abgelehnt",
+	},
+	PaymentApprovedDirect: {
+		English: "Your payment of %s was approved, thank you!",
+		German:  "Deine Zahlung von %s wurde freigegeben, danke!",
+	},
+	PaymentRejectedDirect: {
+		English: "Your payment of %s was rejected, ask a treasurer if you think this is a mistake",
+		German:  "Deine Zahlung von %s wurde abgelehnt, frag einen Schatzmeister, wenn du das für einen Fehler hältst",
+	},
+	PaymentTooHigh: {
+		English: ":x: Payment of %s by <@%s> rejected, it exceeds the debt that was corrected in the meantime (approved by %s)",
+		German:  ":x: Zahlung von %s durch <@%s> abgelehnt, sie übersteigt die inzwischen korrigierten Schulden (freigegeben von %s)",
+	},
+	PaymentTooHighDirect: {
+		English: "Your payment of %s was rejected, it exceeds your debt that was corrected in the meantime. Please report it again.",
+		German:  "Deine Zahlung von %s wurde abgelehnt, sie übersteigt deine inzwischen korrigierten Schulden. Bitte melde sie erneut.",
+	},
+	PaymentAlreadyHandled: {
+		English: "This payment was already approved or rejected",
+		German:  "Diese Zahlung wurde bereits freigegeben oder abgelehnt",
+	},
+	PaymentApproveFailed: {
+		English: "Could not approve payment, the debt may have been corrected in the meantime",
+		German:  "Die Zahlung konnte nicht freigegeben werden, eventuell wurden die Schulden zwischenzeitlich korrigiert",
+	},
+	HistoryFailed: {
+		English: "Could not get history",
+		German:  "Der Verlauf konnte nicht geladen werden",
+	},
+	HistoryEmpty: {
+		English: "No entries",
+		German:  "Keine Einträge",
+	},
+	HistoryTitle: {
+		English: ":scroll: History",
+		German:  ":scroll: Verlauf",
+	},
+	HistoryPage: {
+		English: "Page %d",
+		German:  "Seite %d",
+	},
+	PlayersEmpty: {
+		English: "There are no registered players",
+		German:  "Es sind keine Spieler registriert",
+	},
+	PlayersPage: {
+		English: "Players, page %d of %d",
+		German:  "Spieler, Seite %d von %d",
+	},
+	SetChannelFailed: {
+		English: "Could not set channel",
+		German:  "Der Channel konnte nicht gesetzt werden",
+	},
+	SetupCheckFailed: {
+		English: "Could not check if already setup",
+		German:  "Es konnte nicht geprüft werden, ob der Bot schon eingerichtet ist",
+	},
+	SetupDeleteFailed: {
+		English: "Could not delete messages and current setup",
+		German:  "Die Nachrichten und die bisherige Einrichtung konnten nicht gelöscht werden",
+	},
+	SetupSaveFailed: {
+		English: "Could not save setup",
+		German:  "Die Einrichtung konnte nicht gespeichert werden",
+	},
+	SetupGetFailed: {
+		English: "Could not get setup",
+		German:  "Die Einrichtung konnte nicht geladen werden",
+	},
+	RegistrationFailed: {
+		English: "Could not send registration message",
+		German:  "Die Anmeldenachricht konnte nicht gesendet werden",
+	},
+	DebtsMessageFailed: {
+		English: "Could not send debts message",
+		German:  "Die Schuldentafel konnte nicht gesendet werden",
+	},
+	ChannelSet: {
+		English: "Channel set successfully",
+		German:  "Channel erfolgreich gesetzt",
+	},
+	CorrectFailed: {
+		English: "Could not correct debt",
+		German:  "Die Schulden konnten nicht korrigiert werden",
+	},
+	Corrected: {
+		English: "Debt corrected successfully",
+		German:  "Schulden erfolgreich korrigiert",
+	},
+	PenaltyAmountFailed: {
+		English: "Could not set penalty amount",
+		German:  "Der Betrag der Strafe konnte nicht gesetzt werden",
+	},
+	PenaltyAmountSet: {
+		English: "Penalty amount set to %s",
+		German:  "Betrag der Strafe auf %s gesetzt",
+	},
+	DeletePlayerFailed: {
+		English: "Could not delete player",
+		German:  "Der Spieler konnte nicht gelöscht werden",
+	},
+	PlayerDeleted: {
+		English: "Player deleted together with their debt and history",
+		German:  "Spieler samt Schulden und Verlauf gelöscht",
+	},
+	AdminRoleFailed: {
+		English: "Could not set admin role",
+		German:  "Die Admin-Rolle konnte nicht gesetzt werden",
+	},
+	AdminRoleRemoved: {
+		English: "Admin role removed, only members with the Manage Server permission are admins now",
+		German:  "Admin-Rolle entfernt, nur Mitglieder mit der Berechtigung Server verwalten sind jetzt Admins",
+	},
+	AdminRoleSet: {
+		English: "Members with the role <@&%s> are admins now",
+		German:  "Mitglieder mit der Rolle <@&%s> sind jetzt Admins",
+	},
+	TreasurerFailed: {
+		English: "Could not set treasurer channel",
+		German:  "Der Schatzmeister-Channel konnte nicht gesetzt werden",
+	},
+	TreasurerRemoved: {
+		English: "Treasurer channel removed, payments are applied right away again",
+		German:  "Schatzmeister-Channel entfernt, Zahlungen werden wieder sofort verbucht",
+	},
+	TreasurerSet: {
+		English: "Payments are posted to <#%s> and only applied once an admin approves them",
+		German:  "Zahlungen werden in <#%s> gemeldet und erst verbucht, wenn ein Admin sie freigibt",
+	},
+	SettingsFailed: {
+		English: "Could not get settings",
+		German:  "Die Einstellungen konnten nicht geladen werden",
+	},
+	SettingsUpdateFailed: {
+		English: "Could not update settings",
+		German:  "Die Einstellungen konnten nicht gespeichert werden",
+	},
+	SettingsInvalid: {
+		English: "These settings are not valid",
+		German:  "Diese Einstellungen sind nicht gültig",
+	},
+	SettingsDefault: {
+		English: "default",
+		German:  "Standard",
+	},
+	SettingsEmojiChanged: {
+		English: "Players register with %s now and reactions with %s are ignored. " +
+			"Registered players stay on the board for a week, ask them to react with the new emoji.",
+		German: "Spieler registrieren sich jetzt mit %s und Reaktionen mit %s werden ignoriert. " +
+			"Registrierte Spieler bleiben eine Woche auf der Tafel, bitte sie, mit dem neuen Emoji zu reagieren.",
+	},
+	ConfigInvalidEmoji: {
+		English: "'%s' is not an emoji",
+		German:  "'%s' ist kein Emoji",
+	},
+	ConfigInvalidColor: {
+		English: "'%s' is not a hex color, try e.g. #F1C40F",
+		German:  "'%s' ist keine Hex-Farbe, versuche z.B. #F1C40F",
+	},
+	ConfigInvalidSortOrder: {
+		English: "'%s' is not a sort order, use %s or %s",
+		German:  "'%s' ist keine Sortierung, nutze %s oder %s",
+	},
+	ConfigInvalidLanguage: {
+		English: "'%s' is not a language, use %s",
+		German:  "'%s' ist keine Sprache, nutze %s",
+	},
+	ConfigTitleTooLong: {
+		English: "The title must not be longer than %d characters",
+		German:  "Der Titel darf nicht länger als %d Zeichen sein",
+	},
+	ConfigUnknownKey: {
+		English: "Unknown setting '%s'",
+		German:  "Unbekannte Einstellung '%s'",
+	},
+	ConfigInvalidWeekday: {
+		English: "'%s' is not a weekday, use %s",
+		German:  "'%s' ist kein Wochentag, nutze %s",
+	},
+	ConfigInvalidTime: {
+		English: "'%s' is not a time, try e.g. 19:30",
+		German:  "'%s' ist keine Uhrzeit, versuche z.B. 19:30",
+	},
+	ConfigInvalidTimezone: {
+		English: "'%s' is not a timezone, try e.g. Europe/Berlin",
+		German:  "'%s' ist keine Zeitzone, versuche z.B. Europe/Berlin",
+	},
+	ConfigInvalidThreshold: {
+		English: "'%s' is not an amount of 0 or more",
+		German:  "'%s' ist kein Betrag von 0 oder mehr",
+	},
+	ReminderMessage: {
+		English: ":bell: Reminder: you owe %s to the guild bank of %s. " +
+			"Pay with the button on the board, or turn these reminders off with /10k reminders off.",
+		German: ":bell: Erinnerung: Du schuldest der Gildenbank von %[2]s noch %[1]s. " +
+			"Bezahle mit dem Button an der Tafel oder schalte diese Erinnerungen mit /10k erinnerungen aus ab.",
+	},
+	RemindersOn: {
+		English: "You will be reminded of your debts again",
+		German:  "Du wirst wieder an deine Schulden erinnert",
+	},
+	RemindersOff: {
+		English: "You will not be reminded of your debts anymore",
+		German:  "Du wirst nicht mehr an deine Schulden erinnert",
+	},
+	RemindersFailed: {
+		English: "Could not change your reminders",
+		German:  "Deine Erinnerungen konnten nicht geändert werden",
+	},
+	SummaryTitle: {
+		English: ":calendar: Weekly summary",
+		German:  ":calendar: Wochenrückblick",
+	},
+	SummaryPenalties: {
+		English: "Penalties",
+		German:  "Strafen",
+	},
+	SummaryPenaltiesValue: {
+		English: "%d for %s",
+		German:  "%d über %s",
+	},
+	SummaryPayments: {
+		English: "Payments",
+		German:  "Zahlungen",
+	},
+	SummaryPaymentsValue: {
+		English: "%d for %s",
+		German:  "%d über %s",
+	},
+	SummaryNetChange: {
+		English: "Net change",
+		German:  "Veränderung",
+	},
+	SummaryNetChangeValue: {
+		English: "%s (week before: %s)",
+		German:  "%s (Vorwoche: %s)",
+	},
+	SummaryBiggestDebtors: {
+		English: "Biggest debtors",
+		German:  "Größte Schuldner",
+	},
+	SummaryDebtFree: {
+		English: "Debt-free",
+		German:  "Schuldenfrei",
+	},
+	SummaryNobody: {
+		English: "Nobody",
+		German:  "Niemand",
+	},
+	SummaryFailed: {
+		English: "Could not summarize the week",
+		German:  "Die Woche konnte nicht zusammengefasst werden",
+	},
+	ExportReady: {
+		English: "Exported %d rows",
+		German:  "%d Zeilen exportiert",
+	},
+	ExportInvalidRange: {
+		English: "Give the dates like 2024-03-31, from must not be after to",
+		German:  "Gib die Daten wie 2024-03-31 an, von darf nicht nach bis liegen",
+	},
+	ExportFailed: {
+		English: "Could not export",
+		German:  "Der Export ist fehlgeschlagen",
+	},
+	ImportPreview: {
+		English: "The import changes %d debts, adds %d players and skips %d rows. Apply it?",
+		German:  "Der Import ändert %d Schulden, fügt %d Spieler hinzu und überspringt %d Zeilen. Anwenden?",
+	},
+	ImportNothing: {
+		English: "The import changes nothing, %d rows match no player",
+		German:  "Der Import ändert nichts, %d Zeilen passen zu keinem Spieler",
+	},
+	ImportSkippedRow: {
+		English: "row %d matches no player",
+		German:  "Zeile %d passt zu keinem Spieler",
+	},
+	ImportApplied: {
+		English: "Imported %d rows",
+		German:  "%d Zeilen importiert",
+	},
+	ImportCancelled: {
+		English: "Import cancelled",
+		German:  "Import abgebrochen",
+	},
+	ImportFailed: {
+		English: "Could not import",
+		German:  "Der Import ist fehlgeschlagen",
+	},
+	ImportTooLarge: {
+		English: "The file must not be larger than %d KB",
+		German:  "Die Datei darf nicht größer als %d KB sein",
+	},
+	ImportTooManyRows: {
+		English: "At most %d rows can be imported at once",
+		German:  "Es können höchstens %d Zeilen auf einmal importiert werden",
+	},
+	ImportInvalidFile: {
+		English: "The file is not a CSV file",
+		German:  "Die Datei ist keine CSV-Datei",
+	},
+	ImportInvalidHeader: {
+		English: "The header needs a discord_id or name column and a balance column",
+		German:  "Die Kopfzeile braucht eine Spalte discord_id oder name und eine Spalte balance",
+	},
+	ImportInvalidRow: {
+		English: "Row %d needs a discord id or name and a balance that is not negative",
+		German:  "Zeile %d braucht eine Discord-ID oder einen Namen und einen Betrag, der nicht negativ ist",
+	},
+	ImportEmpty: {
+		English: "The file contains no rows",
+		German:  "Die Datei enthält keine Zeilen",
+	},
+	ImportDuplicate: {
+		English: "A player is in the file twice, every player can only be imported once",
+		German:  "Ein Spieler ist doppelt in der Datei, jeder Spieler kann nur einmal importiert werden",
+	},
+	ApiTokenCreated: {
+		English: "The API token of this server, it is only shown once and replaces the previous one:\n||`%s`||\nSend it as `Authorization: Bearer <token>` to `/api/guilds/%s/...`",
+		German:  "Der API-Token dieses Servers, er wird nur einmal angezeigt und ersetzt den vorherigen:\n||`%s`||\nSende ihn als `Authorization: Bearer <token>` an `/api/guilds/%s/...`",
+	},
+	ApiTokenRevoked: {
+		English: "The API token was revoked",
+		German:  "Der API-Token wurde widerrufen",
+	},
+	ApiTokenNone: {
+		English: "This server has no API token",
+		German:  "Dieser Server hat keinen API-Token",
+	},
+	ApiTokenFailed: {
+		English: "Could not change the API token",
+		German:  "Der API-Token konnte nicht geändert werden",
+	},
+}
@@ -15,6 +15,7 @@ import (
 	db "slash10k/pkg/db"
 	sqlc "slash10k/sql/gen"
 
+	pgtype "github.com/jackc/pgx/v5/pgtype"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -158,6 +159,36 @@ func (mr *MockQueriesMockRecorder) AddJournalEntry(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddJournalEntry", reflect.TypeOf((*MockQueries)(nil).AddJournalEntry), arg0, arg1)
 }
 
+// AddPenaltyCategory mocks base method.
+func (m *MockQueries) AddPenaltyCategory(arg0 context.Context, arg1 sqlc.AddPenaltyCategoryParams) (sqlc.PenaltyCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPenaltyCategory", arg0, arg1)
+	ret0, _ := ret[0].(sqlc.PenaltyCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPenaltyCategory indicates an expected call of AddPenaltyCategory.
+func (mr *MockQueriesMockRecorder) AddPenaltyCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPenaltyCategory", reflect.TypeOf((*MockQueries)(nil).AddPenaltyCategory), arg0, arg1)
+}
+
+// AddPendingPayment mocks base method.
+func (m *MockQueries) AddPendingPayment(arg0 context.Context, arg1 sqlc.AddPendingPaymentParams) (sqlc.PendingPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPendingPayment", arg0, arg1)
+	ret0, _ := ret[0].(sqlc.PendingPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPendingPayment indicates an expected call of AddPendingPayment.
+func (mr *MockQueriesMockRecorder) AddPendingPayment(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPendingPayment", reflect.TypeOf((*MockQueries)(nil).AddPendingPayment), arg0, arg1)
+}
+
 // AddPlayer mocks base method.
 func (m *MockQueries) AddPlayer(arg0 context.Context, arg1 sqlc.AddPlayerParams) (sqlc.Player, error) {
 	m.ctrl.T.Helper()
@@ -173,6 +204,80 @@ func (mr *MockQueriesMockRecorder) AddPlayer(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPlayer", reflect.TypeOf((*MockQueries)(nil).AddPlayer), arg0, arg1)
 }
 
+// AddReminder mocks base method.
+func (m *MockQueries) AddReminder(arg0 context.Context, arg1 sqlc.AddReminderParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReminder", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReminder indicates an expected call of AddReminder.
+func (mr *MockQueriesMockRecorder) AddReminder(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReminder", reflect.TypeOf((*MockQueries)(nil).AddReminder), arg0, arg1)
+}
+
+// AddReminderOptOut mocks base method.
+func (m *MockQueries) AddReminderOptOut(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReminderOptOut", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReminderOptOut indicates an expected call of AddReminderOptOut.
+func (mr *MockQueriesMockRecorder) AddReminderOptOut(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReminderOptOut", reflect.TypeOf((*MockQueries)(nil).AddReminderOptOut), arg0, arg1)
+}
+
+// AddToDebt mocks base method.
+func (m *MockQueries) AddToDebt(arg0 context.Context, arg1 sqlc.AddToDebtParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToDebt", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddToDebt indicates an expected call of AddToDebt.
+func (mr *MockQueriesMockRecorder) AddToDebt(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToDebt", reflect.TypeOf((*MockQueries)(nil).AddToDebt), arg0, arg1)
+}
+
+// AddWeeklySummary mocks base method.
+func (m *MockQueries) AddWeeklySummary(arg0 context.Context, arg1 sqlc.AddWeeklySummaryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWeeklySummary", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWeeklySummary indicates an expected call of AddWeeklySummary.
+func (mr *MockQueriesMockRecorder) AddWeeklySummary(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWeeklySummary", reflect.TypeOf((*MockQueries)(nil).AddWeeklySummary), arg0, arg1)
+}
+
+// DeleteApiToken mocks base method.
+func (m *MockQueries) DeleteApiToken(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteApiToken", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteApiToken indicates an expected call of DeleteApiToken.
+func (mr *MockQueriesMockRecorder) DeleteApiToken(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApiToken", reflect.TypeOf((*MockQueries)(nil).DeleteApiToken), arg0, arg1)
+}
+
 // DeleteBotSetup mocks base method.
 func (m *MockQueries) DeleteBotSetup(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -187,6 +292,20 @@ func (mr *MockQueriesMockRecorder) DeleteBotSetup(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBotSetup", reflect.TypeOf((*MockQueries)(nil).DeleteBotSetup), arg0, arg1)
 }
 
+// DeleteGuildSettings mocks base method.
+func (m *MockQueries) DeleteGuildSettings(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGuildSettings", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGuildSettings indicates an expected call of DeleteGuildSettings.
+func (mr *MockQueriesMockRecorder) DeleteGuildSettings(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGuildSettings", reflect.TypeOf((*MockQueries)(nil).DeleteGuildSettings), arg0, arg1)
+}
+
 // DeleteJournalEntry mocks base method.
 func (m *MockQueries) DeleteJournalEntry(arg0 context.Context, arg1 int32) error {
 	m.ctrl.T.Helper()
@@ -201,6 +320,48 @@ func (mr *MockQueriesMockRecorder) DeleteJournalEntry(arg0, arg1 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteJournalEntry", reflect.TypeOf((*MockQueries)(nil).DeleteJournalEntry), arg0, arg1)
 }
 
+// DeletePenaltyCategoriesOfGuild mocks base method.
+func (m *MockQueries) DeletePenaltyCategoriesOfGuild(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePenaltyCategoriesOfGuild", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePenaltyCategoriesOfGuild indicates an expected call of DeletePenaltyCategoriesOfGuild.
+func (mr *MockQueriesMockRecorder) DeletePenaltyCategoriesOfGuild(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePenaltyCategoriesOfGuild", reflect.TypeOf((*MockQueries)(nil).DeletePenaltyCategoriesOfGuild), arg0, arg1)
+}
+
+// DeletePenaltyCategory mocks base method.
+func (m *MockQueries) DeletePenaltyCategory(arg0 context.Context, arg1 sqlc.DeletePenaltyCategoryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePenaltyCategory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePenaltyCategory indicates an expected call of DeletePenaltyCategory.
+func (mr *MockQueriesMockRecorder) DeletePenaltyCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePenaltyCategory", reflect.TypeOf((*MockQueries)(nil).DeletePenaltyCategory), arg0, arg1)
+}
+
+// DeletePendingPayment mocks base method.
+func (m *MockQueries) DeletePendingPayment(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePendingPayment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePendingPayment indicates an expected call of DeletePendingPayment.
+func (mr *MockQueriesMockRecorder) DeletePendingPayment(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePendingPayment", reflect.TypeOf((*MockQueries)(nil).DeletePendingPayment), arg0, arg1)
+}
+
 // DeletePlayer mocks base method.
 func (m *MockQueries) DeletePlayer(arg0 context.Context, arg1 int32) error {
 	m.ctrl.T.Helper()
@@ -215,6 +376,91 @@ func (mr *MockQueriesMockRecorder) DeletePlayer(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlayer", reflect.TypeOf((*MockQueries)(nil).DeletePlayer), arg0, arg1)
 }
 
+// DeletePlayersOfGuild mocks base method.
+func (m *MockQueries) DeletePlayersOfGuild(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlayersOfGuild", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlayersOfGuild indicates an expected call of DeletePlayersOfGuild.
+func (mr *MockQueriesMockRecorder) DeletePlayersOfGuild(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlayersOfGuild", reflect.TypeOf((*MockQueries)(nil).DeletePlayersOfGuild), arg0, arg1)
+}
+
+// DeleteReminderOptOut mocks base method.
+func (m *MockQueries) DeleteReminderOptOut(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReminderOptOut", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReminderOptOut indicates an expected call of DeleteReminderOptOut.
+func (mr *MockQueriesMockRecorder) DeleteReminderOptOut(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReminderOptOut", reflect.TypeOf((*MockQueries)(nil).DeleteReminderOptOut), arg0, arg1)
+}
+
+// DeleteRemindersBefore mocks base method.
+func (m *MockQueries) DeleteRemindersBefore(arg0 context.Context, arg1 sqlc.DeleteRemindersBeforeParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRemindersBefore", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRemindersBefore indicates an expected call of DeleteRemindersBefore.
+func (mr *MockQueriesMockRecorder) DeleteRemindersBefore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRemindersBefore", reflect.TypeOf((*MockQueries)(nil).DeleteRemindersBefore), arg0, arg1)
+}
+
+// DeleteWeeklySummariesBefore mocks base method.
+func (m *MockQueries) DeleteWeeklySummariesBefore(arg0 context.Context, arg1 sqlc.DeleteWeeklySummariesBeforeParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWeeklySummariesBefore", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWeeklySummariesBefore indicates an expected call of DeleteWeeklySummariesBefore.
+func (mr *MockQueriesMockRecorder) DeleteWeeklySummariesBefore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWeeklySummariesBefore", reflect.TypeOf((*MockQueries)(nil).DeleteWeeklySummariesBefore), arg0, arg1)
+}
+
+// DeleteWeeklySummariesOfGuild mocks base method.
+func (m *MockQueries) DeleteWeeklySummariesOfGuild(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWeeklySummariesOfGuild", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWeeklySummariesOfGuild indicates an expected call of DeleteWeeklySummariesOfGuild.
+func (mr *MockQueriesMockRecorder) DeleteWeeklySummariesOfGuild(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWeeklySummariesOfGuild", reflect.TypeOf((*MockQueries)(nil).DeleteWeeklySummariesOfGuild), arg0, arg1)
+}
+
+// DoGuildSettingsExist mocks base method.
+func (m *MockQueries) DoGuildSettingsExist(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DoGuildSettingsExist", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DoGuildSettingsExist indicates an expected call of DoGuildSettingsExist.
+func (mr *MockQueriesMockRecorder) DoGuildSettingsExist(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoGuildSettingsExist", reflect.TypeOf((*MockQueries)(nil).DoGuildSettingsExist), arg0, arg1)
+}
+
 // DoesBotSetupExist mocks base method.
 func (m *MockQueries) DoesBotSetupExist(arg0 context.Context, arg1 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -230,6 +476,21 @@ func (mr *MockQueriesMockRecorder) DoesBotSetupExist(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoesBotSetupExist", reflect.TypeOf((*MockQueries)(nil).DoesBotSetupExist), arg0, arg1)
 }
 
+// DoesPenaltyCategoryExist mocks base method.
+func (m *MockQueries) DoesPenaltyCategoryExist(arg0 context.Context, arg1 sqlc.DoesPenaltyCategoryExistParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DoesPenaltyCategoryExist", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DoesPenaltyCategoryExist indicates an expected call of DoesPenaltyCategoryExist.
+func (mr *MockQueriesMockRecorder) DoesPenaltyCategoryExist(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoesPenaltyCategoryExist", reflect.TypeOf((*MockQueries)(nil).DoesPenaltyCategoryExist), arg0, arg1)
+}
+
 // DoesPlayerExist mocks base method.
 func (m *MockQueries) DoesPlayerExist(arg0 context.Context, arg1 sqlc.DoesPlayerExistParams) (bool, error) {
 	m.ctrl.T.Helper()
@@ -275,6 +536,21 @@ func (mr *MockQueriesMockRecorder) GetAllPlayers(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPlayers", reflect.TypeOf((*MockQueries)(nil).GetAllPlayers), arg0, arg1)
 }
 
+// GetAllPlayersWithInactive mocks base method.
+func (m *MockQueries) GetAllPlayersWithInactive(arg0 context.Context, arg1 string) ([]sqlc.GetAllPlayersWithInactiveRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllPlayersWithInactive", arg0, arg1)
+	ret0, _ := ret[0].([]sqlc.GetAllPlayersWithInactiveRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllPlayersWithInactive indicates an expected call of GetAllPlayersWithInactive.
+func (mr *MockQueriesMockRecorder) GetAllPlayersWithInactive(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPlayersWithInactive", reflect.TypeOf((*MockQueries)(nil).GetAllPlayersWithInactive), arg0, arg1)
+}
+
 // GetBotSetup mocks base method.
 func (m *MockQueries) GetBotSetup(arg0 context.Context, arg1 string) (sqlc.BotSetup, error) {
 	m.ctrl.T.Helper()
@@ -290,6 +566,66 @@ func (mr *MockQueriesMockRecorder) GetBotSetup(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBotSetup", reflect.TypeOf((*MockQueries)(nil).GetBotSetup), arg0, arg1)
 }
 
+// GetGuildOfApiToken mocks base method.
+func (m *MockQueries) GetGuildOfApiToken(arg0 context.Context, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuildOfApiToken", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGuildOfApiToken indicates an expected call of GetGuildOfApiToken.
+func (mr *MockQueriesMockRecorder) GetGuildOfApiToken(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuildOfApiToken", reflect.TypeOf((*MockQueries)(nil).GetGuildOfApiToken), arg0, arg1)
+}
+
+// GetGuildSettings mocks base method.
+func (m *MockQueries) GetGuildSettings(arg0 context.Context, arg1 string) (sqlc.GuildSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuildSettings", arg0, arg1)
+	ret0, _ := ret[0].(sqlc.GuildSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGuildSettings indicates an expected call of GetGuildSettings.
+func (mr *MockQueriesMockRecorder) GetGuildSettings(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuildSettings", reflect.TypeOf((*MockQueries)(nil).GetGuildSettings), arg0, arg1)
+}
+
+// GetGuildSettingsWithReminders mocks base method.
+func (m *MockQueries) GetGuildSettingsWithReminders(arg0 context.Context) ([]sqlc.GuildSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuildSettingsWithReminders", arg0)
+	ret0, _ := ret[0].([]sqlc.GuildSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGuildSettingsWithReminders indicates an expected call of GetGuildSettingsWithReminders.
+func (mr *MockQueriesMockRecorder) GetGuildSettingsWithReminders(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuildSettingsWithReminders", reflect.TypeOf((*MockQueries)(nil).GetGuildSettingsWithReminders), arg0)
+}
+
+// GetGuildSettingsWithSummaries mocks base method.
+func (m *MockQueries) GetGuildSettingsWithSummaries(arg0 context.Context) ([]sqlc.GuildSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuildSettingsWithSummaries", arg0)
+	ret0, _ := ret[0].([]sqlc.GuildSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGuildSettingsWithSummaries indicates an expected call of GetGuildSettingsWithSummaries.
+func (mr *MockQueriesMockRecorder) GetGuildSettingsWithSummaries(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuildSettingsWithSummaries", reflect.TypeOf((*MockQueries)(nil).GetGuildSettingsWithSummaries), arg0)
+}
+
 // GetIdOfPlayer mocks base method.
 func (m *MockQueries) GetIdOfPlayer(arg0 context.Context, arg1 sqlc.GetIdOfPlayerParams) (int32, error) {
 	m.ctrl.T.Helper()
@@ -320,6 +656,126 @@ func (mr *MockQueriesMockRecorder) GetJournalEntries(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJournalEntries", reflect.TypeOf((*MockQueries)(nil).GetJournalEntries), arg0, arg1)
 }
 
+// GetJournalEntriesOfGuild mocks base method.
+func (m *MockQueries) GetJournalEntriesOfGuild(arg0 context.Context, arg1 sqlc.GetJournalEntriesOfGuildParams) ([]sqlc.GetJournalEntriesOfGuildRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJournalEntriesOfGuild", arg0, arg1)
+	ret0, _ := ret[0].([]sqlc.GetJournalEntriesOfGuildRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJournalEntriesOfGuild indicates an expected call of GetJournalEntriesOfGuild.
+func (mr *MockQueriesMockRecorder) GetJournalEntriesOfGuild(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJournalEntriesOfGuild", reflect.TypeOf((*MockQueries)(nil).GetJournalEntriesOfGuild), arg0, arg1)
+}
+
+// GetJournalEntriesOfGuildBetween mocks base method.
+func (m *MockQueries) GetJournalEntriesOfGuildBetween(arg0 context.Context, arg1 sqlc.GetJournalEntriesOfGuildBetweenParams) ([]sqlc.GetJournalEntriesOfGuildBetweenRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJournalEntriesOfGuildBetween", arg0, arg1)
+	ret0, _ := ret[0].([]sqlc.GetJournalEntriesOfGuildBetweenRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJournalEntriesOfGuildBetween indicates an expected call of GetJournalEntriesOfGuildBetween.
+func (mr *MockQueriesMockRecorder) GetJournalEntriesOfGuildBetween(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJournalEntriesOfGuildBetween", reflect.TypeOf((*MockQueries)(nil).GetJournalEntriesOfGuildBetween), arg0, arg1)
+}
+
+// GetJournalEntriesOfPlayer mocks base method.
+func (m *MockQueries) GetJournalEntriesOfPlayer(arg0 context.Context, arg1 sqlc.GetJournalEntriesOfPlayerParams) ([]sqlc.GetJournalEntriesOfPlayerRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJournalEntriesOfPlayer", arg0, arg1)
+	ret0, _ := ret[0].([]sqlc.GetJournalEntriesOfPlayerRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJournalEntriesOfPlayer indicates an expected call of GetJournalEntriesOfPlayer.
+func (mr *MockQueriesMockRecorder) GetJournalEntriesOfPlayer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJournalEntriesOfPlayer", reflect.TypeOf((*MockQueries)(nil).GetJournalEntriesOfPlayer), arg0, arg1)
+}
+
+// GetOrphanedGuilds mocks base method.
+func (m *MockQueries) GetOrphanedGuilds(arg0 context.Context, arg1 pgtype.Timestamp) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrphanedGuilds", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrphanedGuilds indicates an expected call of GetOrphanedGuilds.
+func (mr *MockQueriesMockRecorder) GetOrphanedGuilds(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrphanedGuilds", reflect.TypeOf((*MockQueries)(nil).GetOrphanedGuilds), arg0, arg1)
+}
+
+// GetPenaltyCategories mocks base method.
+func (m *MockQueries) GetPenaltyCategories(arg0 context.Context, arg1 string) ([]sqlc.PenaltyCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPenaltyCategories", arg0, arg1)
+	ret0, _ := ret[0].([]sqlc.PenaltyCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPenaltyCategories indicates an expected call of GetPenaltyCategories.
+func (mr *MockQueriesMockRecorder) GetPenaltyCategories(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPenaltyCategories", reflect.TypeOf((*MockQueries)(nil).GetPenaltyCategories), arg0, arg1)
+}
+
+// GetPenaltyCategory mocks base method.
+func (m *MockQueries) GetPenaltyCategory(arg0 context.Context, arg1 sqlc.GetPenaltyCategoryParams) (sqlc.PenaltyCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPenaltyCategory", arg0, arg1)
+	ret0, _ := ret[0].(sqlc.PenaltyCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPenaltyCategory indicates an expected call of GetPenaltyCategory.
+func (mr *MockQueriesMockRecorder) GetPenaltyCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPenaltyCategory", reflect.TypeOf((*MockQueries)(nil).GetPenaltyCategory), arg0, arg1)
+}
+
+// GetPendingPaymentForUpdate mocks base method.
+func (m *MockQueries) GetPendingPaymentForUpdate(arg0 context.Context, arg1 sqlc.GetPendingPaymentForUpdateParams) (sqlc.GetPendingPaymentForUpdateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingPaymentForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(sqlc.GetPendingPaymentForUpdateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingPaymentForUpdate indicates an expected call of GetPendingPaymentForUpdate.
+func (mr *MockQueriesMockRecorder) GetPendingPaymentForUpdate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingPaymentForUpdate", reflect.TypeOf((*MockQueries)(nil).GetPendingPaymentForUpdate), arg0, arg1)
+}
+
+// GetPendingPaymentSum mocks base method.
+func (m *MockQueries) GetPendingPaymentSum(arg0 context.Context, arg1 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingPaymentSum", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingPaymentSum indicates an expected call of GetPendingPaymentSum.
+func (mr *MockQueriesMockRecorder) GetPendingPaymentSum(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingPaymentSum", reflect.TypeOf((*MockQueries)(nil).GetPendingPaymentSum), arg0, arg1)
+}
+
 // GetPlayer mocks base method.
 func (m *MockQueries) GetPlayer(arg0 context.Context, arg1 sqlc.GetPlayerParams) (sqlc.GetPlayerRow, error) {
 	m.ctrl.T.Helper()
@@ -335,6 +791,65 @@ func (mr *MockQueriesMockRecorder) GetPlayer(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlayer", reflect.TypeOf((*MockQueries)(nil).GetPlayer), arg0, arg1)
 }
 
+// GetPlayerForUpdate mocks base method.
+func (m *MockQueries) GetPlayerForUpdate(arg0 context.Context, arg1 sqlc.GetPlayerForUpdateParams) (sqlc.GetPlayerForUpdateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlayerForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(sqlc.GetPlayerForUpdateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlayerForUpdate indicates an expected call of GetPlayerForUpdate.
+func (mr *MockQueriesMockRecorder) GetPlayerForUpdate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlayerForUpdate", reflect.TypeOf((*MockQueries)(nil).GetPlayerForUpdate), arg0, arg1)
+}
+
+// GetPlayersToRemind mocks base method.
+func (m *MockQueries) GetPlayersToRemind(arg0 context.Context, arg1 sqlc.GetPlayersToRemindParams) ([]sqlc.GetPlayersToRemindRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlayersToRemind", arg0, arg1)
+	ret0, _ := ret[0].([]sqlc.GetPlayersToRemindRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlayersToRemind indicates an expected call of GetPlayersToRemind.
+func (mr *MockQueriesMockRecorder) GetPlayersToRemind(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlayersToRemind", reflect.TypeOf((*MockQueries)(nil).GetPlayersToRemind), arg0, arg1)
+}
+
+// MarkGuildOrphaned mocks base method.
+func (m *MockQueries) MarkGuildOrphaned(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkGuildOrphaned", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkGuildOrphaned indicates an expected call of MarkGuildOrphaned.
+func (mr *MockQueriesMockRecorder) MarkGuildOrphaned(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkGuildOrphaned", reflect.TypeOf((*MockQueries)(nil).MarkGuildOrphaned), arg0, arg1)
+}
+
+// NumberOfPenaltyCategories mocks base method.
+func (m *MockQueries) NumberOfPenaltyCategories(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NumberOfPenaltyCategories", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NumberOfPenaltyCategories indicates an expected call of NumberOfPenaltyCategories.
+func (mr *MockQueriesMockRecorder) NumberOfPenaltyCategories(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NumberOfPenaltyCategories", reflect.TypeOf((*MockQueries)(nil).NumberOfPenaltyCategories), arg0, arg1)
+}
+
 // NumberOfPlayers mocks base method.
 func (m *MockQueries) NumberOfPlayers(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
@@ -350,6 +865,20 @@ func (mr *MockQueriesMockRecorder) NumberOfPlayers(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NumberOfPlayers", reflect.TypeOf((*MockQueries)(nil).NumberOfPlayers), arg0)
 }
 
+// PutApiToken mocks base method.
+func (m *MockQueries) PutApiToken(arg0 context.Context, arg1 sqlc.PutApiTokenParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutApiToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutApiToken indicates an expected call of PutApiToken.
+func (mr *MockQueriesMockRecorder) PutApiToken(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutApiToken", reflect.TypeOf((*MockQueries)(nil).PutApiToken), arg0, arg1)
+}
+
 // PutBotSetup mocks base method.
 func (m *MockQueries) PutBotSetup(arg0 context.Context, arg1 sqlc.PutBotSetupParams) (sqlc.BotSetup, error) {
 	m.ctrl.T.Helper()
@@ -365,6 +894,35 @@ func (mr *MockQueriesMockRecorder) PutBotSetup(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutBotSetup", reflect.TypeOf((*MockQueries)(nil).PutBotSetup), arg0, arg1)
 }
 
+// PutGuildSettings mocks base method.
+func (m *MockQueries) PutGuildSettings(arg0 context.Context, arg1 sqlc.PutGuildSettingsParams) (sqlc.GuildSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutGuildSettings", arg0, arg1)
+	ret0, _ := ret[0].(sqlc.GuildSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutGuildSettings indicates an expected call of PutGuildSettings.
+func (mr *MockQueriesMockRecorder) PutGuildSettings(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutGuildSettings", reflect.TypeOf((*MockQueries)(nil).PutGuildSettings), arg0, arg1)
+}
+
+// SetBotSetupAwaitingReactions mocks base method.
+func (m *MockQueries) SetBotSetupAwaitingReactions(arg0 context.Context, arg1 sqlc.SetBotSetupAwaitingReactionsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBotSetupAwaitingReactions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBotSetupAwaitingReactions indicates an expected call of SetBotSetupAwaitingReactions.
+func (mr *MockQueriesMockRecorder) SetBotSetupAwaitingReactions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBotSetupAwaitingReactions", reflect.TypeOf((*MockQueries)(nil).SetBotSetupAwaitingReactions), arg0, arg1)
+}
+
 // SetDebt mocks base method.
 func (m *MockQueries) SetDebt(arg0 context.Context, arg1 sqlc.SetDebtParams) error {
 	m.ctrl.T.Helper()
@@ -379,6 +937,48 @@ func (mr *MockQueriesMockRecorder) SetDebt(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDebt", reflect.TypeOf((*MockQueries)(nil).SetDebt), arg0, arg1)
 }
 
+// SetPlayerActive mocks base method.
+func (m *MockQueries) SetPlayerActive(arg0 context.Context, arg1 sqlc.SetPlayerActiveParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPlayerActive", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPlayerActive indicates an expected call of SetPlayerActive.
+func (mr *MockQueriesMockRecorder) SetPlayerActive(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPlayerActive", reflect.TypeOf((*MockQueries)(nil).SetPlayerActive), arg0, arg1)
+}
+
+// UnmarkGuildOrphaned mocks base method.
+func (m *MockQueries) UnmarkGuildOrphaned(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmarkGuildOrphaned", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmarkGuildOrphaned indicates an expected call of UnmarkGuildOrphaned.
+func (mr *MockQueriesMockRecorder) UnmarkGuildOrphaned(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmarkGuildOrphaned", reflect.TypeOf((*MockQueries)(nil).UnmarkGuildOrphaned), arg0, arg1)
+}
+
+// UpdateBotSetupMessages mocks base method.
+func (m *MockQueries) UpdateBotSetupMessages(arg0 context.Context, arg1 sqlc.UpdateBotSetupMessagesParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotSetupMessages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotSetupMessages indicates an expected call of UpdateBotSetupMessages.
+func (mr *MockQueriesMockRecorder) UpdateBotSetupMessages(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotSetupMessages", reflect.TypeOf((*MockQueries)(nil).UpdateBotSetupMessages), arg0, arg1)
+}
+
 // UpdateJournalEntry mocks base method.
 func (m *MockQueries) UpdateJournalEntry(arg0 context.Context, arg1 sqlc.UpdateJournalEntryParams) (sqlc.DebtJournal, error) {
 	m.ctrl.T.Helper()
@@ -394,6 +994,21 @@ func (mr *MockQueriesMockRecorder) UpdateJournalEntry(arg0, arg1 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJournalEntry", reflect.TypeOf((*MockQueries)(nil).UpdateJournalEntry), arg0, arg1)
 }
 
+// UpdatePlayerName mocks base method.
+func (m *MockQueries) UpdatePlayerName(arg0 context.Context, arg1 sqlc.UpdatePlayerNameParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePlayerName", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePlayerName indicates an expected call of UpdatePlayerName.
+func (mr *MockQueriesMockRecorder) UpdatePlayerName(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlayerName", reflect.TypeOf((*MockQueries)(nil).UpdatePlayerName), arg0, arg1)
+}
+
 // MockTransaction is a mock of Transaction interface.
 type MockTransaction struct {
 	ctrl     *gomock.Controller
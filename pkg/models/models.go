@@ -3,6 +3,7 @@ package models
 import (
 	"sort"
 	"strings"
+	"time"
 )
 
 type Players []Player
@@ -15,14 +16,37 @@ func (p Players) SortByName() {
 	)
 }
 
+// SortByDebt puts the players with the highest debt first, players with the same debt are sorted by name.
+func (p Players) SortByDebt() {
+	sort.Slice(
+		p, func(i, j int) bool {
+			if p[i].Debt.Amount != p[j].Debt.Amount {
+				return p[i].Debt.Amount > p[j].Debt.Amount
+			}
+			return strings.Compare(p[i].Name, p[j].Name) < 0
+		},
+	)
+}
+
+func (p Players) Sort(order SortOrder) {
+	if order == SortOrderDebt {
+		p.SortByDebt()
+		return
+	}
+	p.SortByName()
+}
+
 type Player struct {
 	Id          int32
 	DiscordId   string
 	DiscordName string
 	GuildId     string
 	Name        string
+	Active      bool
 	Debt        Debt
 	DebtJournal []DebtJournalEntry
+	// PendingPayment is the sum of the payments of the player that wait for the approval of a treasurer.
+	PendingPayment int64
 }
 
 type Debt struct {
@@ -40,6 +64,9 @@ type DebtJournalEntry struct {
 	Date        int64
 	UserId      int32
 	GuildId     string
+	Category    string
+	DiscordId   string
+	PlayerName  string
 }
 
 type BotSetup struct {
@@ -47,4 +74,145 @@ type BotSetup struct {
 	ChannelId             string
 	RegistrationMessageId string
 	DebtsMessageId        string
+	// AwaitingReactionsUntil is set while the registration message lacks the reactions of some active
+	// players, e.g. after it was restored or the emoji changed. Players without reaction are not deactivated
+	// before this unix time, it is 0 if no reactions are awaited.
+	AwaitingReactionsUntil int64
+}
+
+// AwaitingReactions reports whether players without reaction are kept at the given time.
+func (b BotSetup) AwaitingReactions(now time.Time) bool {
+	return now.Unix() < b.AwaitingReactionsUntil
+}
+
+// PendingPayment is a payment a player reported, it is applied to the debt once a treasurer approves it.
+type PendingPayment struct {
+	Id         int32
+	GuildId    string
+	DiscordId  string
+	PlayerName string
+	Amount     int64
+	CreatedAt  int64
+}
+
+type PenaltyCategory struct {
+	Id      int32
+	GuildId string
+	Name    string
+	Amount  int64
+}
+
+// SortOrder is the order of the players on the board.
+type SortOrder string
+
+const (
+	SortOrderName SortOrder = "name"
+	SortOrderDebt SortOrder = "debt"
+)
+
+const (
+	DefaultPenaltyAmount     int64 = 10000
+	DefaultRegistrationEmoji       = "💰"
+	DefaultBoardTitle              = "" // the title in the language of the guild
+	DefaultBoardColor        int32 = 0xF1C40F
+	DefaultSortOrder               = SortOrderName
+	DefaultReminderWeekday         = ScheduleOff
+	DefaultReminderTime      int32 = 19 * 60
+	DefaultTimezone                = "UTC"
+	DefaultSummaryWeekday          = ScheduleOff
+	DefaultSummaryTime       int32 = 19 * 60
+
+	// ScheduleOff is the weekday of schedules that are turned off, e.g. of guilds that do not remind their debtors.
+	ScheduleOff time.Weekday = -1
+
+	// BoardTitleAmount is replaced by the penalty amount in the title of the board.
+	BoardTitleAmount = "{amount}"
+)
+
+type GuildSettings struct {
+	GuildId       string
+	PenaltyAmount int64
+	AdminRoleId   string
+	// RegistrationEmoji is the reaction that registers a player, either a unicode emoji
+	// or a custom emoji in the form name:id.
+	RegistrationEmoji string
+	BoardTitle        string
+	BoardColor        int32
+	SortOrder         SortOrder
+	// Locale is the language of the bot in this guild, if empty the bot speaks the language
+	// of the Discord client for interactions and the preferred language of the guild otherwise.
+	Locale string
+	// TreasurerChannelId is the channel payments are approved in, if empty payments are applied right away.
+	TreasurerChannelId string
+	// ReminderWeekday is the day debtors are reminded in a direct message, or ScheduleOff.
+	ReminderWeekday time.Weekday
+	// ReminderTime is the time of the reminders in minutes after midnight.
+	ReminderTime int32
+	// ReminderThreshold is the debt a player has to exceed to be reminded.
+	ReminderThreshold int64
+	// Timezone is the IANA name of the timezone the schedules of the guild are in.
+	Timezone string
+	// SummaryWeekday is the day the weekly summary is posted in the channel of the bot, or ScheduleOff.
+	SummaryWeekday time.Weekday
+	// SummaryTime is the time of the weekly summary in minutes after midnight.
+	SummaryTime int32
+}
+
+func DefaultGuildSettings(guildId string) GuildSettings {
+	return GuildSettings{
+		GuildId:           guildId,
+		PenaltyAmount:     DefaultPenaltyAmount,
+		RegistrationEmoji: DefaultRegistrationEmoji,
+		BoardTitle:        DefaultBoardTitle,
+		BoardColor:        DefaultBoardColor,
+		SortOrder:         DefaultSortOrder,
+		ReminderWeekday:   DefaultReminderWeekday,
+		ReminderTime:      DefaultReminderTime,
+		Timezone:          DefaultTimezone,
+		SummaryWeekday:    DefaultSummaryWeekday,
+		SummaryTime:       DefaultSummaryTime,
+	}
+}
+
+// WeeklySummary is a recap of the debts of a guild over the seven days before End. Only the journal
+// entries of penalties and payments are counted as such, corrections, resets and imports only change
+// the net change.
+type WeeklySummary struct {
+	GuildId      string
+	Start        int64
+	End          int64
+	Penalties    int64
+	PenaltyCount int
+	Payments     int64
+	PaymentCount int
+	NetChange    int64
+	// PreviousNetChange is the net change of the seven days before Start.
+	PreviousNetChange int64
+	// BiggestDebtors are the players with the highest debt at the end of the week, the highest first.
+	BiggestDebtors Players
+	// DebtFree are the players without debt at the end of the week.
+	DebtFree Players
+}
+
+// ImportRow sets the debt of a player to Amount, the player is given by discord id or name.
+type ImportRow struct {
+	Line   int
+	Player string
+	Amount int64
+	Note   string
+}
+
+// ImportChange is what an ImportRow changes. Player is empty if the row matches no one, the row is
+// skipped then. New players are registered and inactive players reactivated by the import, Description
+// is recorded in the journal.
+type ImportChange struct {
+	Row         ImportRow
+	Player      Player
+	New         bool
+	Description string
+}
+
+// Skipped reports whether the row of the change matches no player.
+func (c ImportChange) Skipped() bool {
+	return c.Player.DiscordId == ""
 }
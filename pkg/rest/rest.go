@@ -0,0 +1,251 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"net/http"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultJournalLimit = 100
+	MaxJournalLimit     = 1000
+
+	ReadHeaderTimeout = 5 * time.Second
+	ShutdownTimeout   = 5 * time.Second
+)
+
+// Player mirrors models.Player.
+type Player struct {
+	Id             int32              `json:"id"`
+	DiscordId      string             `json:"discordId"`
+	DiscordName    string             `json:"discordName"`
+	GuildId        string             `json:"guildId"`
+	Name           string             `json:"name"`
+	Active         bool               `json:"active"`
+	Debt           Debt               `json:"debt"`
+	DebtJournal    []DebtJournalEntry `json:"debtJournal,omitempty"`
+	PendingPayment int64              `json:"pendingPayment"`
+}
+
+// Debt mirrors models.Debt, LastUpdated is in seconds since the epoch.
+type Debt struct {
+	Id          int32  `json:"id"`
+	Amount      int64  `json:"amount"`
+	LastUpdated int64  `json:"lastUpdated"`
+	UserId      int32  `json:"userId"`
+	GuildId     string `json:"guildId"`
+}
+
+// DebtJournalEntry mirrors models.DebtJournalEntry, Date is in seconds since the epoch.
+type DebtJournalEntry struct {
+	Id          int32  `json:"id"`
+	Amount      int64  `json:"amount"`
+	Description string `json:"description"`
+	Date        int64  `json:"date"`
+	UserId      int32  `json:"userId"`
+	GuildId     string `json:"guildId"`
+	Category    string `json:"category"`
+	DiscordId   string `json:"discordId"`
+	PlayerName  string `json:"playerName"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewHandler serves the read-only api. Every request needs the api token of the guild it asks for
+// as bearer token, see domain.Service.CreateApiToken.
+func NewHandler(service domain.Service) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /api/guilds/{guildId}/players", authorized(service, getPlayers(service)))
+	mux.Handle("GET /api/guilds/{guildId}/players/{discordId}", authorized(service, getPlayer(service)))
+	mux.Handle("GET /api/guilds/{guildId}/journal", authorized(service, getJournal(service)))
+	return mux
+}
+
+// ListenAndServe serves the api on the address until the context is done.
+func ListenAndServe(ctx context.Context, address string, service domain.Service) error {
+	server := &http.Server{
+		Addr:              address,
+		Handler:           NewHandler(service),
+		ReadHeaderTimeout: ReadHeaderTimeout,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	log.Info().Msgf("serving api on %s", address)
+	err := server.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+func authorized(service domain.Service, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		guildId := r.PathValue("guildId")
+		log.Info().Msgf("api %s called for guild %s", r.URL.Path, guildId)
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, "missing api token")
+			return
+		}
+		tokenGuildId, err := service.GetGuildOfApiToken(r.Context(), token)
+		if errors.Is(err, domain.ErrApiTokenDoesNotExist) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, "invalid api token")
+			return
+		} else if err != nil {
+			log.Error().Msgf("cannot get guild of api token: %s", err)
+			writeError(w, http.StatusInternalServerError, "could not check api token")
+			return
+		}
+		if tokenGuildId != guildId {
+			writeError(w, http.StatusForbidden, "api token is not valid for this guild")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// getPlayers returns the active and the inactive players, inactive players left the board but kept their debt.
+func getPlayers(service domain.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		players, err := service.GetAllPlayersWithInactive(r.Context(), r.PathValue("guildId"))
+		if err != nil {
+			log.Error().Msgf("cannot get players: %s", err)
+			writeError(w, http.StatusInternalServerError, "could not get players")
+			return
+		}
+		models.Players(players).SortByName()
+		res := make([]Player, len(players))
+		for i, player := range players {
+			res[i] = fromPlayer(player)
+		}
+		writeJson(w, http.StatusOK, res)
+	}
+}
+
+// getPlayer finds inactive players as well, like getPlayers.
+func getPlayer(service domain.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		player, err := service.GetPlayer(r.Context(), r.PathValue("discordId"), r.PathValue("guildId"))
+		if errors.Is(err, domain.ErrPlayerDoesNotExist) {
+			writeError(w, http.StatusNotFound, "player does not exist")
+			return
+		} else if err != nil {
+			log.Error().Msgf("cannot get player: %s", err)
+			writeError(w, http.StatusInternalServerError, "could not get player")
+			return
+		}
+		writeJson(w, http.StatusOK, fromPlayer(*player))
+	}
+}
+
+// getJournal returns the latest journal entries first. They can be paged with limit and offset and
+// filtered by the discord id of a player and by the penalty category.
+func getJournal(service domain.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		limit, err := intParameter(query.Get("limit"), DefaultJournalLimit)
+		if err != nil || limit < 1 || limit > MaxJournalLimit {
+			writeError(w, http.StatusBadRequest, "limit must be between 1 and "+strconv.Itoa(MaxJournalLimit))
+			return
+		}
+		offset, err := intParameter(query.Get("offset"), 0)
+		if err != nil || offset < 0 {
+			writeError(w, http.StatusBadRequest, "offset must not be negative")
+			return
+		}
+
+		entries, err := service.GetHistory(
+			r.Context(),
+			r.PathValue("guildId"),
+			query.Get("player"),
+			query.Get("category"),
+			limit,
+			offset,
+		)
+		if err != nil {
+			log.Error().Msgf("cannot get journal: %s", err)
+			writeError(w, http.StatusInternalServerError, "could not get journal")
+			return
+		}
+		res := make([]DebtJournalEntry, len(entries))
+		for i, entry := range entries {
+			res[i] = fromDebtJournalEntry(entry)
+		}
+		writeJson(w, http.StatusOK, res)
+	}
+}
+
+func intParameter(value string, defaultValue int32) (int32, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	i, err := strconv.ParseInt(value, 10, 32)
+	return int32(i), err
+}
+
+func fromPlayer(player models.Player) Player {
+	res := Player{
+		Id:          player.Id,
+		DiscordId:   player.DiscordId,
+		DiscordName: player.DiscordName,
+		GuildId:     player.GuildId,
+		Name:        player.Name,
+		Active:      player.Active,
+		Debt: Debt{
+			Id:          player.Debt.Id,
+			Amount:      player.Debt.Amount,
+			LastUpdated: player.Debt.LastUpdated,
+			UserId:      player.Debt.UserId,
+			GuildId:     player.Debt.GuildId,
+		},
+		PendingPayment: player.PendingPayment,
+	}
+	for _, entry := range player.DebtJournal {
+		res.DebtJournal = append(res.DebtJournal, fromDebtJournalEntry(entry))
+	}
+	return res
+}
+
+func fromDebtJournalEntry(entry models.DebtJournalEntry) DebtJournalEntry {
+	return DebtJournalEntry{
+		Id:          entry.Id,
+		Amount:      entry.Amount,
+		Description: entry.Description,
+		Date:        entry.Date,
+		UserId:      entry.UserId,
+		GuildId:     entry.GuildId,
+		Category:    entry.Category,
+		DiscordId:   entry.DiscordId,
+		PlayerName:  entry.PlayerName,
+	}
+}
+
+func writeJson(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(body)
+	if err != nil {
+		log.Error().Msgf("cannot write response: %s", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJson(w, status, errorResponse{Error: message})
+}
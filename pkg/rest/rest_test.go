@@ -0,0 +1,180 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slash10k/pkg/domain"
+	"slash10k/pkg/models"
+	"slash10k/pkg/rest"
+	"testing"
+)
+
+const (
+	testGuildId = "309323862326116352"
+	testToken   = "token"
+)
+
+// service answers the calls of the api, all other calls panic.
+type service struct {
+	domain.Service
+	players []models.Player
+	history func(discordId string, category string, limit int32, offset int32) []models.DebtJournalEntry
+}
+
+func (s service) GetGuildOfApiToken(_ context.Context, token string) (string, error) {
+	if token != testToken {
+		return "", domain.ErrApiTokenDoesNotExist
+	}
+	return testGuildId, nil
+}
+
+func (s service) GetAllPlayersWithInactive(context.Context, string) ([]models.Player, error) {
+	return s.players, nil
+}
+
+func (s service) GetPlayer(_ context.Context, discordId string, _ string) (*models.Player, error) {
+	for _, player := range s.players {
+		if player.DiscordId == discordId {
+			return &player, nil
+		}
+	}
+	return nil, domain.ErrPlayerDoesNotExist
+}
+
+func (s service) GetHistory(
+	_ context.Context,
+	_ string,
+	discordId string,
+	category string,
+	limit int32,
+	offset int32,
+) ([]models.DebtJournalEntry, error) {
+	return s.history(discordId, category, limit, offset), nil
+}
+
+func Test_Handler(t *testing.T) {
+	s := service{
+		players: []models.Player{
+			{DiscordId: "2", Name: "torfstack", Active: true, Debt: models.Debt{Amount: 30000}, PendingPayment: 10000},
+			{DiscordId: "1", Name: "neruh", Debt: models.Debt{Amount: 5000}},
+		},
+		history: func(discordId string, category string, limit int32, offset int32) []models.DebtJournalEntry {
+			if discordId != "2" || category != "raid" || limit != 10 || offset != 20 {
+				t.Errorf("Unexpected history of %q in %q, limit %d and offset %d", discordId, category, limit, offset)
+			}
+			return []models.DebtJournalEntry{{Id: 7, Amount: 10000, DiscordId: "2", Category: "raid"}}
+		},
+	}
+	tests := []struct {
+		name       string
+		path       string
+		token      string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "players sorted by name",
+			path:       "/api/guilds/" + testGuildId + "/players",
+			token:      testToken,
+			wantStatus: http.StatusOK,
+			wantBody: `[{"id":0,"discordId":"1","discordName":"","guildId":"","name":"neruh","active":false,` +
+				`"debt":{"id":0,"amount":5000,"lastUpdated":0,"userId":0,"guildId":""},"pendingPayment":0},` +
+				`{"id":0,"discordId":"2","discordName":"","guildId":"","name":"torfstack","active":true,` +
+				`"debt":{"id":0,"amount":30000,"lastUpdated":0,"userId":0,"guildId":""},"pendingPayment":10000}]`,
+		},
+		{
+			name:       "player",
+			path:       "/api/guilds/" + testGuildId + "/players/2",
+			token:      testToken,
+			wantStatus: http.StatusOK,
+			wantBody: `{"id":0,"discordId":"2","discordName":"","guildId":"","name":"torfstack","active":true,` +
+				`"debt":{"id":0,"amount":30000,"lastUpdated":0,"userId":0,"guildId":""},"pendingPayment":10000}`,
+		},
+		{
+			name:       "inactive player",
+			path:       "/api/guilds/" + testGuildId + "/players/1",
+			token:      testToken,
+			wantStatus: http.StatusOK,
+			wantBody: `{"id":0,"discordId":"1","discordName":"","guildId":"","name":"neruh","active":false,` +
+				`"debt":{"id":0,"amount":5000,"lastUpdated":0,"userId":0,"guildId":""},"pendingPayment":0}`,
+		},
+		{
+			name:       "unknown player",
+			path:       "/api/guilds/" + testGuildId + "/players/3",
+			token:      testToken,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "journal",
+			path:       "/api/guilds/" + testGuildId + "/journal?player=2&category=raid&limit=10&offset=20",
+			token:      testToken,
+			wantStatus: http.StatusOK,
+			wantBody: `[{"id":7,"amount":10000,"description":"","date":0,"userId":0,"guildId":"",` +
+				`"category":"raid","discordId":"2","playerName":""}]`,
+		},
+		{
+			name:       "journal limit too high",
+			path:       "/api/guilds/" + testGuildId + "/journal?limit=1001",
+			token:      testToken,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "journal with negative offset",
+			path:       "/api/guilds/" + testGuildId + "/journal?offset=-1",
+			token:      testToken,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing token",
+			path:       "/api/guilds/" + testGuildId + "/players",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "invalid token",
+			path:       "/api/guilds/" + testGuildId + "/players",
+			token:      "guess",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "token of another guild",
+			path:       "/api/guilds/1/players",
+			token:      testToken,
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	handler := rest.NewHandler(s)
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+				if tt.token != "" {
+					req.Header.Set("Authorization", "Bearer "+tt.token)
+				}
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+
+				if rec.Code != tt.wantStatus {
+					t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body)
+				}
+				if !json.Valid(rec.Body.Bytes()) {
+					t.Fatalf("Expected a json body, got %s", rec.Body)
+				}
+				if tt.wantBody != "" && rec.Body.String() != tt.wantBody+"\n" {
+					t.Errorf("Expected body %s, got %s", tt.wantBody, rec.Body)
+				}
+			},
+		)
+	}
+}
+
+func Test_HandlerIsReadOnly(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/guilds/"+testGuildId+"/players", nil)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	rec := httptest.NewRecorder()
+	rest.NewHandler(service{}).ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status %d, got %d", http.StatusMethodNotAllowed, rec.Code)
+	}
+}
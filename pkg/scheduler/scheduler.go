@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultInterval is how often the jobs are run, schedules are precise to the minute.
+const DefaultInterval = time.Minute
+
+// Job is run by the scheduler on every tick. It decides on its own which of its work is due at the given time.
+type Job func(ctx context.Context, now time.Time)
+
+// Scheduler runs jobs periodically, e.g. the reminders of all guilds on their own schedule.
+type Scheduler struct {
+	interval time.Duration
+	jobs     []Job
+}
+
+func New(interval time.Duration, jobs ...Job) *Scheduler {
+	return &Scheduler{interval: interval, jobs: jobs}
+}
+
+// Run runs the jobs one after the other once per interval. The first run is one interval after the start,
+// so that the bot is connected by then and jobs can send messages. It blocks until the context is done.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, job := range s.jobs {
+				job(ctx, now)
+			}
+		}
+	}
+}
+
+// Weekly is a point in time that repeats every week, e.g. Monday at 19:00 in Europe/Berlin.
+type Weekly struct {
+	Weekday time.Weekday
+	// Minute is the time of the day in minutes after midnight.
+	Minute   int32
+	Location *time.Location
+}
+
+// Previous returns the latest occurrence that is not after now.
+func (w Weekly) Previous(now time.Time) time.Time {
+	local := now.In(w.Location)
+	days := (int(local.Weekday()) - int(w.Weekday) + 7) % 7
+	year, month, day := local.Date()
+	occurrence := time.Date(year, month, day-days, 0, int(w.Minute), 0, 0, w.Location)
+	if occurrence.After(now) {
+		occurrence = time.Date(year, month, day-days-7, 0, int(w.Minute), 0, 0, w.Location)
+	}
+	return occurrence
+}
+
+// Due returns the latest occurrence and whether it is less than the window ago. The window allows
+// to catch up on an occurrence that was missed, e.g. because the bot was restarted at that time.
+func (w Weekly) Due(now time.Time, window time.Duration) (time.Time, bool) {
+	occurrence := w.Previous(now)
+	return occurrence, now.Sub(occurrence) < window
+}
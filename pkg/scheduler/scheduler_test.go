@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func Test_Weekly_Previous(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Could not load location: %s", err)
+	}
+	monday := Weekly{Weekday: time.Monday, Minute: 19 * 60, Location: berlin}
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "same day after the time",
+			now:  time.Date(2024, 3, 11, 20, 0, 0, 0, berlin),
+			want: time.Date(2024, 3, 11, 19, 0, 0, 0, berlin),
+		},
+		{
+			name: "exactly at the time",
+			now:  time.Date(2024, 3, 11, 19, 0, 0, 0, berlin),
+			want: time.Date(2024, 3, 11, 19, 0, 0, 0, berlin),
+		},
+		{
+			name: "same day before the time",
+			now:  time.Date(2024, 3, 11, 18, 59, 0, 0, berlin),
+			want: time.Date(2024, 3, 4, 19, 0, 0, 0, berlin),
+		},
+		{
+			name: "later in the week",
+			now:  time.Date(2024, 3, 14, 8, 0, 0, 0, berlin),
+			want: time.Date(2024, 3, 11, 19, 0, 0, 0, berlin),
+		},
+		{
+			name: "across the change to daylight saving time",
+			now:  time.Date(2024, 4, 1, 19, 30, 0, 0, berlin),
+			want: time.Date(2024, 4, 1, 19, 0, 0, 0, berlin),
+		},
+		{
+			name: "now in another timezone",
+			now:  time.Date(2024, 3, 11, 18, 30, 0, 0, time.UTC),
+			want: time.Date(2024, 3, 11, 19, 0, 0, 0, berlin),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				if got := monday.Previous(tt.now); !got.Equal(tt.want) {
+					t.Errorf("Previous() = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
+
+func Test_Weekly_Due(t *testing.T) {
+	monday := Weekly{Weekday: time.Monday, Minute: 19 * 60, Location: time.UTC}
+	if _, due := monday.Due(time.Date(2024, 3, 11, 19, 30, 0, 0, time.UTC), time.Hour); !due {
+		t.Errorf("Expected to be due half an hour after the time")
+	}
+	if _, due := monday.Due(time.Date(2024, 3, 11, 20, 0, 0, 0, time.UTC), time.Hour); due {
+		t.Errorf("Expected not to be due once the window passed")
+	}
+}
+
+func Test_Scheduler_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var runs atomic.Int32
+	job := func(ctx context.Context, now time.Time) {
+		if runs.Add(1) == 3 {
+			cancel()
+		}
+	}
+	done := make(chan struct{})
+	go func() {
+		New(time.Millisecond, job).Run(ctx)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected scheduler to stop once the context is done")
+	}
+	if runs.Load() != 3 {
+		t.Errorf("Expected 3 runs, got %d", runs.Load())
+	}
+}
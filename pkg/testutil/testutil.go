@@ -2,7 +2,6 @@ package testutil
 
 import (
 	"go.uber.org/mock/gomock"
-	"slash10k/pkg/config"
 	"slash10k/pkg/db"
 	mockdb "slash10k/pkg/mocks"
 	sqlc "slash10k/sql/gen"
@@ -32,8 +31,7 @@ func QueriesMock(c *gomock.Controller) (db.Database, *mockdb.MockQueries) {
 		Return(mockQueries)
 	mockConn.EXPECT().
 		Close(gomock.Any()).
-		MinTimes(1).
-		Return(nil)
+		MinTimes(1)
 	mockTx.EXPECT().
 		Commit(gomock.Any()).
 		AnyTimes().
@@ -47,8 +45,10 @@ func WithoutError(t *testing.T, a interface{}, err error) {
 	}
 }
 
+const testGuildId = "309323862326116352"
+
 func TestGuildIdString() string {
-	return string(config.TorfstackServerGuildIdString)
+	return testGuildId
 }
 
 func AddPlayerParams(discordId string) sqlc.AddPlayerParams {
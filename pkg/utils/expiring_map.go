@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// ExpiringMap is a map whose entries expire after a fixed time to live. It holds at most
+// maxSize entries, storing more evicts the oldest ones.
+type ExpiringMap[K comparable, V any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	maxSize int
+	now     func() time.Time
+	entries map[K]*list.Element
+	order   *list.List
+}
+
+type expiringEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+func NewExpiringMap[K comparable, V any](ttl time.Duration, maxSize int) *ExpiringMap[K, V] {
+	return &ExpiringMap[K, V]{
+		ttl:     ttl,
+		maxSize: maxSize,
+		now:     time.Now,
+		entries: make(map[K]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (m *ExpiringMap[K, V]) Store(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.evictExpired()
+	if e, ok := m.entries[key]; ok {
+		m.remove(e)
+	}
+	for m.order.Len() >= m.maxSize {
+		m.remove(m.order.Front())
+	}
+	m.entries[key] = m.order.PushBack(
+		&expiringEntry[K, V]{
+			key:       key,
+			value:     value,
+			expiresAt: m.now().Add(m.ttl),
+		},
+	)
+}
+
+func (m *ExpiringMap[K, V]) Load(key K) (value V, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.evictExpired()
+	e, ok := m.entries[key]
+	if !ok {
+		return
+	}
+	return e.Value.(*expiringEntry[K, V]).value, true
+}
+
+func (m *ExpiringMap[K, V]) LoadAndRemove(key K) (value V, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.evictExpired()
+	e, ok := m.entries[key]
+	if !ok {
+		return
+	}
+	m.remove(e)
+	return e.Value.(*expiringEntry[K, V]).value, true
+}
+
+func (m *ExpiringMap[K, V]) Contains(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.evictExpired()
+	_, ok := m.entries[key]
+	return ok
+}
+
+func (m *ExpiringMap[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.evictExpired()
+	return m.order.Len()
+}
+
+// evictExpired removes expired entries from the front, as all entries share the same time
+// to live the list is ordered by expiry.
+func (m *ExpiringMap[K, V]) evictExpired() {
+	now := m.now()
+	for e := m.order.Front(); e != nil; e = m.order.Front() {
+		if e.Value.(*expiringEntry[K, V]).expiresAt.After(now) {
+			return
+		}
+		m.remove(e)
+	}
+}
+
+func (m *ExpiringMap[K, V]) remove(e *list.Element) {
+	m.order.Remove(e)
+	delete(m.entries, e.Value.(*expiringEntry[K, V]).key)
+}
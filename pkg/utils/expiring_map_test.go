@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_ExpiringMap(t *testing.T) {
+	now := time.Unix(0, 0)
+	m := NewExpiringMap[string, string](15*time.Minute, 2)
+	m.now = func() time.Time { return now }
+
+	m.Store("a", "token-a")
+	now = now.Add(10 * time.Minute)
+	m.Store("b", "token-b")
+	if m.Len() != 2 {
+		t.Fatalf("Expected 2 entries, got %d", m.Len())
+	}
+
+	now = now.Add(6 * time.Minute)
+	if m.Contains("a") {
+		t.Fatalf("Expected a to be expired")
+	}
+	if v, ok := m.LoadAndRemove("b"); !ok || v != "token-b" {
+		t.Fatalf("Expected token-b, got %q (%v)", v, ok)
+	}
+	if m.Contains("b") {
+		t.Fatalf("Expected b to be removed after loading")
+	}
+
+	m.Store("c", "token-c")
+	m.Store("d", "token-d")
+	m.Store("e", "token-e")
+	if m.Contains("c") || !m.Contains("d") || !m.Contains("e") {
+		t.Fatalf("Expected the oldest entry to be evicted when exceeding the size")
+	}
+}
@@ -8,12 +8,19 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type ApiToken struct {
+	GuildID   string
+	TokenHash string
+	CreatedAt pgtype.Timestamp
+}
+
 type BotSetup struct {
-	GuildID               string
-	ChannelID             string
-	RegistrationMessageID string
-	DebtsMessageID        string
-	CreatedAt             pgtype.Timestamp
+	GuildID                string
+	ChannelID              string
+	RegistrationMessageID  string
+	DebtsMessageID         string
+	CreatedAt              pgtype.Timestamp
+	AwaitingReactionsUntil pgtype.Timestamp
 }
 
 type Debt struct {
@@ -29,6 +36,45 @@ type DebtJournal struct {
 	Description string
 	Date        pgtype.Timestamp
 	UserID      int32
+	Category    string
+}
+
+type GuildSetting struct {
+	GuildID            string
+	PenaltyAmount      int64
+	UpdatedAt          pgtype.Timestamp
+	AdminRoleID        string
+	RegistrationEmoji  string
+	BoardTitle         string
+	BoardColor         int32
+	SortOrder          string
+	Locale             string
+	TreasurerChannelID string
+	ReminderWeekday    int32
+	ReminderTime       int32
+	ReminderThreshold  int64
+	Timezone           string
+	SummaryWeekday     int32
+	SummaryTime        int32
+}
+
+type OrphanedGuild struct {
+	GuildID    string
+	OrphanedAt pgtype.Timestamp
+}
+
+type PenaltyCategory struct {
+	ID      int32
+	GuildID string
+	Name    string
+	Amount  int64
+}
+
+type PendingPayment struct {
+	ID        int32
+	UserID    int32
+	Amount    int64
+	CreatedAt pgtype.Timestamp
 }
 
 type Player struct {
@@ -37,4 +83,22 @@ type Player struct {
 	DiscordName string
 	GuildID     string
 	Name        string
+	Active      bool
+}
+
+type Reminder struct {
+	UserID int32
+	DueAt  pgtype.Timestamp
+	SentAt pgtype.Timestamp
+}
+
+type ReminderOptOut struct {
+	UserID    int32
+	CreatedAt pgtype.Timestamp
+}
+
+type WeeklySummary struct {
+	GuildID  string
+	DueAt    pgtype.Timestamp
+	PostedAt pgtype.Timestamp
 }
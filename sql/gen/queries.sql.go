@@ -7,24 +7,32 @@ package sqlc
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const addJournalEntry = `-- name: AddJournalEntry :one
 INSERT INTO debt_journal (
-    amount, description, user_id
+    amount, description, user_id, category
 ) VALUES (
-    $1, $2, $3
-) RETURNING id, amount, description, date, user_id
+    $1, $2, $3, $4
+) RETURNING id, amount, description, date, user_id, category
 `
 
 type AddJournalEntryParams struct {
 	Amount      int64
 	Description string
 	UserID      int32
+	Category    string
 }
 
 func (q *Queries) AddJournalEntry(ctx context.Context, arg AddJournalEntryParams) (DebtJournal, error) {
-	row := q.db.QueryRow(ctx, addJournalEntry, arg.Amount, arg.Description, arg.UserID)
+	row := q.db.QueryRow(ctx, addJournalEntry,
+		arg.Amount,
+		arg.Description,
+		arg.UserID,
+		arg.Category,
+	)
 	var i DebtJournal
 	err := row.Scan(
 		&i.ID,
@@ -32,6 +40,58 @@ func (q *Queries) AddJournalEntry(ctx context.Context, arg AddJournalEntryParams
 		&i.Description,
 		&i.Date,
 		&i.UserID,
+		&i.Category,
+	)
+	return i, err
+}
+
+const addPenaltyCategory = `-- name: AddPenaltyCategory :one
+INSERT INTO penalty_category (
+    guild_id, name, amount
+) VALUES (
+    $1, $2, $3
+) RETURNING id, guild_id, name, amount
+`
+
+type AddPenaltyCategoryParams struct {
+	GuildID string
+	Name    string
+	Amount  int64
+}
+
+func (q *Queries) AddPenaltyCategory(ctx context.Context, arg AddPenaltyCategoryParams) (PenaltyCategory, error) {
+	row := q.db.QueryRow(ctx, addPenaltyCategory, arg.GuildID, arg.Name, arg.Amount)
+	var i PenaltyCategory
+	err := row.Scan(
+		&i.ID,
+		&i.GuildID,
+		&i.Name,
+		&i.Amount,
+	)
+	return i, err
+}
+
+const addPendingPayment = `-- name: AddPendingPayment :one
+INSERT INTO pending_payment (
+    user_id, amount
+) VALUES (
+    $1, $2
+) RETURNING id, user_id, amount, created_at
+`
+
+type AddPendingPaymentParams struct {
+	UserID int32
+	Amount int64
+}
+
+func (q *Queries) AddPendingPayment(ctx context.Context, arg AddPendingPaymentParams) (PendingPayment, error) {
+	row := q.db.QueryRow(ctx, addPendingPayment, arg.UserID, arg.Amount)
+	var i PendingPayment
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Amount,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -41,7 +101,7 @@ INSERT INTO player (
     discord_id, discord_name, guild_id, name
 ) VALUES (
     $1, $2, $3, $4
-) RETURNING id, discord_id, discord_name, guild_id, name
+) RETURNING id, discord_id, discord_name, guild_id, name, active
 `
 
 type AddPlayerParams struct {
@@ -65,10 +125,100 @@ func (q *Queries) AddPlayer(ctx context.Context, arg AddPlayerParams) (Player, e
 		&i.DiscordName,
 		&i.GuildID,
 		&i.Name,
+		&i.Active,
 	)
 	return i, err
 }
 
+const addReminder = `-- name: AddReminder :execrows
+INSERT INTO reminder (
+    user_id, due_at
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (user_id, due_at) DO NOTHING
+`
+
+type AddReminderParams struct {
+	UserID int32
+	DueAt  pgtype.Timestamp
+}
+
+func (q *Queries) AddReminder(ctx context.Context, arg AddReminderParams) (int64, error) {
+	result, err := q.db.Exec(ctx, addReminder, arg.UserID, arg.DueAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const addReminderOptOut = `-- name: AddReminderOptOut :exec
+INSERT INTO reminder_opt_out (
+    user_id
+) VALUES (
+    $1
+)
+ON CONFLICT (user_id) DO NOTHING
+`
+
+func (q *Queries) AddReminderOptOut(ctx context.Context, userID int32) error {
+	_, err := q.db.Exec(ctx, addReminderOptOut, userID)
+	return err
+}
+
+const addToDebt = `-- name: AddToDebt :one
+UPDATE debt SET amount = amount + $1, last_updated = now()
+WHERE user_id = $2
+RETURNING amount
+`
+
+type AddToDebtParams struct {
+	Amount int64
+	UserID int32
+}
+
+func (q *Queries) AddToDebt(ctx context.Context, arg AddToDebtParams) (int64, error) {
+	row := q.db.QueryRow(ctx, addToDebt, arg.Amount, arg.UserID)
+	var amount int64
+	err := row.Scan(&amount)
+	return amount, err
+}
+
+const addWeeklySummary = `-- name: AddWeeklySummary :execrows
+INSERT INTO weekly_summary (
+    guild_id, due_at
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (guild_id, due_at) DO NOTHING
+`
+
+type AddWeeklySummaryParams struct {
+	GuildID string
+	DueAt   pgtype.Timestamp
+}
+
+func (q *Queries) AddWeeklySummary(ctx context.Context, arg AddWeeklySummaryParams) (int64, error) {
+	result, err := q.db.Exec(ctx, addWeeklySummary, arg.GuildID, arg.DueAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteApiToken = `-- name: DeleteApiToken :execrows
+DELETE FROM api_token
+WHERE guild_id = $1
+`
+
+func (q *Queries) DeleteApiToken(ctx context.Context, guildID string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteApiToken, guildID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const deleteBotSetup = `-- name: DeleteBotSetup :exec
 DELETE FROM bot_setup
 WHERE guild_id = $1
@@ -79,6 +229,16 @@ func (q *Queries) DeleteBotSetup(ctx context.Context, guildID string) error {
 	return err
 }
 
+const deleteGuildSettings = `-- name: DeleteGuildSettings :exec
+DELETE FROM guild_settings
+WHERE guild_id = $1
+`
+
+func (q *Queries) DeleteGuildSettings(ctx context.Context, guildID string) error {
+	_, err := q.db.Exec(ctx, deleteGuildSettings, guildID)
+	return err
+}
+
 const deleteJournalEntry = `-- name: DeleteJournalEntry :exec
 DELETE FROM debt_journal
 WHERE id = $1
@@ -89,6 +249,41 @@ func (q *Queries) DeleteJournalEntry(ctx context.Context, id int32) error {
 	return err
 }
 
+const deletePenaltyCategoriesOfGuild = `-- name: DeletePenaltyCategoriesOfGuild :exec
+DELETE FROM penalty_category
+WHERE guild_id = $1
+`
+
+func (q *Queries) DeletePenaltyCategoriesOfGuild(ctx context.Context, guildID string) error {
+	_, err := q.db.Exec(ctx, deletePenaltyCategoriesOfGuild, guildID)
+	return err
+}
+
+const deletePenaltyCategory = `-- name: DeletePenaltyCategory :exec
+DELETE FROM penalty_category
+WHERE guild_id = $1 AND name = $2
+`
+
+type DeletePenaltyCategoryParams struct {
+	GuildID string
+	Name    string
+}
+
+func (q *Queries) DeletePenaltyCategory(ctx context.Context, arg DeletePenaltyCategoryParams) error {
+	_, err := q.db.Exec(ctx, deletePenaltyCategory, arg.GuildID, arg.Name)
+	return err
+}
+
+const deletePendingPayment = `-- name: DeletePendingPayment :exec
+DELETE FROM pending_payment
+WHERE id = $1
+`
+
+func (q *Queries) DeletePendingPayment(ctx context.Context, id int32) error {
+	_, err := q.db.Exec(ctx, deletePendingPayment, id)
+	return err
+}
+
 const deletePlayer = `-- name: DeletePlayer :exec
 DELETE FROM player
 WHERE id = $1
@@ -99,6 +294,78 @@ func (q *Queries) DeletePlayer(ctx context.Context, id int32) error {
 	return err
 }
 
+const deletePlayersOfGuild = `-- name: DeletePlayersOfGuild :exec
+DELETE FROM player
+WHERE guild_id = $1
+`
+
+func (q *Queries) DeletePlayersOfGuild(ctx context.Context, guildID string) error {
+	_, err := q.db.Exec(ctx, deletePlayersOfGuild, guildID)
+	return err
+}
+
+const deleteReminderOptOut = `-- name: DeleteReminderOptOut :exec
+DELETE FROM reminder_opt_out
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteReminderOptOut(ctx context.Context, userID int32) error {
+	_, err := q.db.Exec(ctx, deleteReminderOptOut, userID)
+	return err
+}
+
+const deleteRemindersBefore = `-- name: DeleteRemindersBefore :exec
+DELETE FROM reminder
+USING player
+WHERE reminder.user_id = player.id AND player.guild_id = $1 AND reminder.due_at < $2
+`
+
+type DeleteRemindersBeforeParams struct {
+	GuildID string
+	DueAt   pgtype.Timestamp
+}
+
+func (q *Queries) DeleteRemindersBefore(ctx context.Context, arg DeleteRemindersBeforeParams) error {
+	_, err := q.db.Exec(ctx, deleteRemindersBefore, arg.GuildID, arg.DueAt)
+	return err
+}
+
+const deleteWeeklySummariesBefore = `-- name: DeleteWeeklySummariesBefore :exec
+DELETE FROM weekly_summary
+WHERE guild_id = $1 AND due_at < $2
+`
+
+type DeleteWeeklySummariesBeforeParams struct {
+	GuildID string
+	DueAt   pgtype.Timestamp
+}
+
+func (q *Queries) DeleteWeeklySummariesBefore(ctx context.Context, arg DeleteWeeklySummariesBeforeParams) error {
+	_, err := q.db.Exec(ctx, deleteWeeklySummariesBefore, arg.GuildID, arg.DueAt)
+	return err
+}
+
+const deleteWeeklySummariesOfGuild = `-- name: DeleteWeeklySummariesOfGuild :exec
+DELETE FROM weekly_summary
+WHERE guild_id = $1
+`
+
+func (q *Queries) DeleteWeeklySummariesOfGuild(ctx context.Context, guildID string) error {
+	_, err := q.db.Exec(ctx, deleteWeeklySummariesOfGuild, guildID)
+	return err
+}
+
+const doGuildSettingsExist = `-- name: DoGuildSettingsExist :one
+SELECT EXISTS(SELECT 1 FROM guild_settings WHERE guild_id = $1)
+`
+
+func (q *Queries) DoGuildSettingsExist(ctx context.Context, guildID string) (bool, error) {
+	row := q.db.QueryRow(ctx, doGuildSettingsExist, guildID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const doesBotSetupExist = `-- name: DoesBotSetupExist :one
 SELECT EXISTS(SELECT 1 FROM bot_setup WHERE guild_id = $1)
 `
@@ -110,6 +377,22 @@ func (q *Queries) DoesBotSetupExist(ctx context.Context, guildID string) (bool,
 	return exists, err
 }
 
+const doesPenaltyCategoryExist = `-- name: DoesPenaltyCategoryExist :one
+SELECT EXISTS(SELECT 1 FROM penalty_category WHERE guild_id = $1 AND name = $2)
+`
+
+type DoesPenaltyCategoryExistParams struct {
+	GuildID string
+	Name    string
+}
+
+func (q *Queries) DoesPenaltyCategoryExist(ctx context.Context, arg DoesPenaltyCategoryExistParams) (bool, error) {
+	row := q.db.QueryRow(ctx, doesPenaltyCategoryExist, arg.GuildID, arg.Name)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const doesPlayerExist = `-- name: DoesPlayerExist :one
 SELECT EXISTS(SELECT 1 FROM player WHERE discord_id = $1 AND guild_id = $2)
 `
@@ -127,7 +410,7 @@ func (q *Queries) DoesPlayerExist(ctx context.Context, arg DoesPlayerExistParams
 }
 
 const getAllBotSetups = `-- name: GetAllBotSetups :many
-SELECT guild_id, channel_id, registration_message_id, debts_message_id, created_at FROM bot_setup
+SELECT guild_id, channel_id, registration_message_id, debts_message_id, created_at, awaiting_reactions_until FROM bot_setup
 `
 
 func (q *Queries) GetAllBotSetups(ctx context.Context) ([]BotSetup, error) {
@@ -145,6 +428,7 @@ func (q *Queries) GetAllBotSetups(ctx context.Context) ([]BotSetup, error) {
 			&i.RegistrationMessageID,
 			&i.DebtsMessageID,
 			&i.CreatedAt,
+			&i.AwaitingReactionsUntil,
 		); err != nil {
 			return nil, err
 		}
@@ -157,14 +441,17 @@ func (q *Queries) GetAllBotSetups(ctx context.Context) ([]BotSetup, error) {
 }
 
 const getAllPlayers = `-- name: GetAllPlayers :many
-SELECT player.id, player.discord_id, player.discord_name, player.guild_id, player.name, debt.id, debt.amount, debt.last_updated, debt.user_id FROM player
+SELECT player.id, player.discord_id, player.discord_name, player.guild_id, player.name, player.active, debt.id, debt.amount, debt.last_updated, debt.user_id,
+    (SELECT COALESCE(SUM(amount), 0) FROM pending_payment WHERE pending_payment.user_id = player.id)::bigint AS pending_amount
+FROM player
 JOIN debt ON player.id = debt.user_id
-WHERE player.guild_id = $1
+WHERE player.guild_id = $1 AND player.active
 `
 
 type GetAllPlayersRow struct {
-	Player Player
-	Debt   Debt
+	Player        Player
+	Debt          Debt
+	PendingAmount int64
 }
 
 func (q *Queries) GetAllPlayers(ctx context.Context, guildID string) ([]GetAllPlayersRow, error) {
@@ -182,10 +469,58 @@ func (q *Queries) GetAllPlayers(ctx context.Context, guildID string) ([]GetAllPl
 			&i.Player.DiscordName,
 			&i.Player.GuildID,
 			&i.Player.Name,
+			&i.Player.Active,
+			&i.Debt.ID,
+			&i.Debt.Amount,
+			&i.Debt.LastUpdated,
+			&i.Debt.UserID,
+			&i.PendingAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllPlayersWithInactive = `-- name: GetAllPlayersWithInactive :many
+SELECT player.id, player.discord_id, player.discord_name, player.guild_id, player.name, player.active, debt.id, debt.amount, debt.last_updated, debt.user_id,
+    (SELECT COALESCE(SUM(amount), 0) FROM pending_payment WHERE pending_payment.user_id = player.id)::bigint AS pending_amount
+FROM player
+JOIN debt ON player.id = debt.user_id
+WHERE player.guild_id = $1
+`
+
+type GetAllPlayersWithInactiveRow struct {
+	Player        Player
+	Debt          Debt
+	PendingAmount int64
+}
+
+func (q *Queries) GetAllPlayersWithInactive(ctx context.Context, guildID string) ([]GetAllPlayersWithInactiveRow, error) {
+	rows, err := q.db.Query(ctx, getAllPlayersWithInactive, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAllPlayersWithInactiveRow
+	for rows.Next() {
+		var i GetAllPlayersWithInactiveRow
+		if err := rows.Scan(
+			&i.Player.ID,
+			&i.Player.DiscordID,
+			&i.Player.DiscordName,
+			&i.Player.GuildID,
+			&i.Player.Name,
+			&i.Player.Active,
 			&i.Debt.ID,
 			&i.Debt.Amount,
 			&i.Debt.LastUpdated,
 			&i.Debt.UserID,
+			&i.PendingAmount,
 		); err != nil {
 			return nil, err
 		}
@@ -198,7 +533,7 @@ func (q *Queries) GetAllPlayers(ctx context.Context, guildID string) ([]GetAllPl
 }
 
 const getBotSetup = `-- name: GetBotSetup :one
-SELECT guild_id, channel_id, registration_message_id, debts_message_id, created_at FROM bot_setup
+SELECT guild_id, channel_id, registration_message_id, debts_message_id, created_at, awaiting_reactions_until FROM bot_setup
 WHERE bot_setup.guild_id = $1 LIMIT 1
 `
 
@@ -211,10 +546,136 @@ func (q *Queries) GetBotSetup(ctx context.Context, guildID string) (BotSetup, er
 		&i.RegistrationMessageID,
 		&i.DebtsMessageID,
 		&i.CreatedAt,
+		&i.AwaitingReactionsUntil,
+	)
+	return i, err
+}
+
+const getGuildOfApiToken = `-- name: GetGuildOfApiToken :one
+SELECT guild_id FROM api_token
+WHERE token_hash = $1
+`
+
+func (q *Queries) GetGuildOfApiToken(ctx context.Context, tokenHash string) (string, error) {
+	row := q.db.QueryRow(ctx, getGuildOfApiToken, tokenHash)
+	var guild_id string
+	err := row.Scan(&guild_id)
+	return guild_id, err
+}
+
+const getGuildSettings = `-- name: GetGuildSettings :one
+SELECT guild_id, penalty_amount, updated_at, admin_role_id, registration_emoji, board_title, board_color, sort_order, locale, treasurer_channel_id, reminder_weekday, reminder_time, reminder_threshold, timezone, summary_weekday, summary_time FROM guild_settings
+WHERE guild_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetGuildSettings(ctx context.Context, guildID string) (GuildSetting, error) {
+	row := q.db.QueryRow(ctx, getGuildSettings, guildID)
+	var i GuildSetting
+	err := row.Scan(
+		&i.GuildID,
+		&i.PenaltyAmount,
+		&i.UpdatedAt,
+		&i.AdminRoleID,
+		&i.RegistrationEmoji,
+		&i.BoardTitle,
+		&i.BoardColor,
+		&i.SortOrder,
+		&i.Locale,
+		&i.TreasurerChannelID,
+		&i.ReminderWeekday,
+		&i.ReminderTime,
+		&i.ReminderThreshold,
+		&i.Timezone,
+		&i.SummaryWeekday,
+		&i.SummaryTime,
 	)
 	return i, err
 }
 
+const getGuildSettingsWithReminders = `-- name: GetGuildSettingsWithReminders :many
+SELECT guild_id, penalty_amount, updated_at, admin_role_id, registration_emoji, board_title, board_color, sort_order, locale, treasurer_channel_id, reminder_weekday, reminder_time, reminder_threshold, timezone, summary_weekday, summary_time FROM guild_settings
+WHERE reminder_weekday >= 0
+`
+
+func (q *Queries) GetGuildSettingsWithReminders(ctx context.Context) ([]GuildSetting, error) {
+	rows, err := q.db.Query(ctx, getGuildSettingsWithReminders)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GuildSetting
+	for rows.Next() {
+		var i GuildSetting
+		if err := rows.Scan(
+			&i.GuildID,
+			&i.PenaltyAmount,
+			&i.UpdatedAt,
+			&i.AdminRoleID,
+			&i.RegistrationEmoji,
+			&i.BoardTitle,
+			&i.BoardColor,
+			&i.SortOrder,
+			&i.Locale,
+			&i.TreasurerChannelID,
+			&i.ReminderWeekday,
+			&i.ReminderTime,
+			&i.ReminderThreshold,
+			&i.Timezone,
+			&i.SummaryWeekday,
+			&i.SummaryTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getGuildSettingsWithSummaries = `-- name: GetGuildSettingsWithSummaries :many
+SELECT guild_id, penalty_amount, updated_at, admin_role_id, registration_emoji, board_title, board_color, sort_order, locale, treasurer_channel_id, reminder_weekday, reminder_time, reminder_threshold, timezone, summary_weekday, summary_time FROM guild_settings
+WHERE summary_weekday >= 0
+`
+
+func (q *Queries) GetGuildSettingsWithSummaries(ctx context.Context) ([]GuildSetting, error) {
+	rows, err := q.db.Query(ctx, getGuildSettingsWithSummaries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GuildSetting
+	for rows.Next() {
+		var i GuildSetting
+		if err := rows.Scan(
+			&i.GuildID,
+			&i.PenaltyAmount,
+			&i.UpdatedAt,
+			&i.AdminRoleID,
+			&i.RegistrationEmoji,
+			&i.BoardTitle,
+			&i.BoardColor,
+			&i.SortOrder,
+			&i.Locale,
+			&i.TreasurerChannelID,
+			&i.ReminderWeekday,
+			&i.ReminderTime,
+			&i.ReminderThreshold,
+			&i.Timezone,
+			&i.SummaryWeekday,
+			&i.SummaryTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getIdOfPlayer = `-- name: GetIdOfPlayer :one
 SELECT id FROM player
 WHERE discord_id = $1 AND guild_id = $2 LIMIT 1
@@ -233,8 +694,9 @@ func (q *Queries) GetIdOfPlayer(ctx context.Context, arg GetIdOfPlayerParams) (i
 }
 
 const getJournalEntries = `-- name: GetJournalEntries :many
-SELECT id, amount, description, date, user_id FROM debt_journal
+SELECT id, amount, description, date, user_id, category FROM debt_journal
 WHERE user_id = $1
+ORDER BY date DESC, id DESC
 `
 
 func (q *Queries) GetJournalEntries(ctx context.Context, userID int32) ([]DebtJournal, error) {
@@ -252,6 +714,7 @@ func (q *Queries) GetJournalEntries(ctx context.Context, userID int32) ([]DebtJo
 			&i.Description,
 			&i.Date,
 			&i.UserID,
+			&i.Category,
 		); err != nil {
 			return nil, err
 		}
@@ -263,31 +726,354 @@ func (q *Queries) GetJournalEntries(ctx context.Context, userID int32) ([]DebtJo
 	return items, nil
 }
 
-const getPlayer = `-- name: GetPlayer :one
-SELECT player.id, player.discord_id, player.discord_name, player.guild_id, player.name, debt.id, debt.amount, debt.last_updated, debt.user_id FROM player
-JOIN debt ON player.id = debt.user_id
-WHERE player.discord_id = $1 AND player.guild_id = $2 LIMIT 1
+const getJournalEntriesOfGuild = `-- name: GetJournalEntriesOfGuild :many
+SELECT debt_journal.id, debt_journal.amount, debt_journal.description, debt_journal.date, debt_journal.user_id, debt_journal.category, player.discord_id, player.name FROM debt_journal
+JOIN player ON player.id = debt_journal.user_id
+WHERE player.guild_id = $1
+  AND ($2::text = '' OR debt_journal.category = $2::text)
+ORDER BY debt_journal.date DESC, debt_journal.id DESC
+LIMIT $3 OFFSET $4
 `
 
-type GetPlayerParams struct {
-	DiscordID string
-	GuildID   string
+type GetJournalEntriesOfGuildParams struct {
+	GuildID  string
+	Category string
+	Lim      int32
+	Off      int32
 }
 
-type GetPlayerRow struct {
-	Player Player
-	Debt   Debt
+type GetJournalEntriesOfGuildRow struct {
+	DebtJournal DebtJournal
+	DiscordID   string
+	Name        string
 }
 
-func (q *Queries) GetPlayer(ctx context.Context, arg GetPlayerParams) (GetPlayerRow, error) {
-	row := q.db.QueryRow(ctx, getPlayer, arg.DiscordID, arg.GuildID)
-	var i GetPlayerRow
-	err := row.Scan(
-		&i.Player.ID,
+func (q *Queries) GetJournalEntriesOfGuild(ctx context.Context, arg GetJournalEntriesOfGuildParams) ([]GetJournalEntriesOfGuildRow, error) {
+	rows, err := q.db.Query(ctx, getJournalEntriesOfGuild,
+		arg.GuildID,
+		arg.Category,
+		arg.Lim,
+		arg.Off,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetJournalEntriesOfGuildRow
+	for rows.Next() {
+		var i GetJournalEntriesOfGuildRow
+		if err := rows.Scan(
+			&i.DebtJournal.ID,
+			&i.DebtJournal.Amount,
+			&i.DebtJournal.Description,
+			&i.DebtJournal.Date,
+			&i.DebtJournal.UserID,
+			&i.DebtJournal.Category,
+			&i.DiscordID,
+			&i.Name,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getJournalEntriesOfGuildBetween = `-- name: GetJournalEntriesOfGuildBetween :many
+SELECT debt_journal.id, debt_journal.amount, debt_journal.description, debt_journal.date, debt_journal.user_id, debt_journal.category, player.discord_id, player.name FROM debt_journal
+JOIN player ON player.id = debt_journal.user_id
+WHERE player.guild_id = $1 AND debt_journal.date >= $2 AND debt_journal.date < $3
+ORDER BY debt_journal.date, debt_journal.id
+`
+
+type GetJournalEntriesOfGuildBetweenParams struct {
+	GuildID  string
+	DateFrom pgtype.Timestamp
+	DateTo   pgtype.Timestamp
+}
+
+type GetJournalEntriesOfGuildBetweenRow struct {
+	DebtJournal DebtJournal
+	DiscordID   string
+	Name        string
+}
+
+func (q *Queries) GetJournalEntriesOfGuildBetween(ctx context.Context, arg GetJournalEntriesOfGuildBetweenParams) ([]GetJournalEntriesOfGuildBetweenRow, error) {
+	rows, err := q.db.Query(ctx, getJournalEntriesOfGuildBetween, arg.GuildID, arg.DateFrom, arg.DateTo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetJournalEntriesOfGuildBetweenRow
+	for rows.Next() {
+		var i GetJournalEntriesOfGuildBetweenRow
+		if err := rows.Scan(
+			&i.DebtJournal.ID,
+			&i.DebtJournal.Amount,
+			&i.DebtJournal.Description,
+			&i.DebtJournal.Date,
+			&i.DebtJournal.UserID,
+			&i.DebtJournal.Category,
+			&i.DiscordID,
+			&i.Name,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getJournalEntriesOfPlayer = `-- name: GetJournalEntriesOfPlayer :many
+SELECT debt_journal.id, debt_journal.amount, debt_journal.description, debt_journal.date, debt_journal.user_id, debt_journal.category, player.discord_id, player.name FROM debt_journal
+JOIN player ON player.id = debt_journal.user_id
+WHERE player.guild_id = $1 AND player.discord_id = $2
+  AND ($3::text = '' OR debt_journal.category = $3::text)
+ORDER BY debt_journal.date DESC, debt_journal.id DESC
+LIMIT $4 OFFSET $5
+`
+
+type GetJournalEntriesOfPlayerParams struct {
+	GuildID   string
+	DiscordID string
+	Category  string
+	Lim       int32
+	Off       int32
+}
+
+type GetJournalEntriesOfPlayerRow struct {
+	DebtJournal DebtJournal
+	DiscordID   string
+	Name        string
+}
+
+func (q *Queries) GetJournalEntriesOfPlayer(ctx context.Context, arg GetJournalEntriesOfPlayerParams) ([]GetJournalEntriesOfPlayerRow, error) {
+	rows, err := q.db.Query(ctx, getJournalEntriesOfPlayer,
+		arg.GuildID,
+		arg.DiscordID,
+		arg.Category,
+		arg.Lim,
+		arg.Off,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetJournalEntriesOfPlayerRow
+	for rows.Next() {
+		var i GetJournalEntriesOfPlayerRow
+		if err := rows.Scan(
+			&i.DebtJournal.ID,
+			&i.DebtJournal.Amount,
+			&i.DebtJournal.Description,
+			&i.DebtJournal.Date,
+			&i.DebtJournal.UserID,
+			&i.DebtJournal.Category,
+			&i.DiscordID,
+			&i.Name,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOrphanedGuilds = `-- name: GetOrphanedGuilds :many
+SELECT guild_id FROM orphaned_guild
+WHERE orphaned_at < $1
+`
+
+func (q *Queries) GetOrphanedGuilds(ctx context.Context, orphanedAt pgtype.Timestamp) ([]string, error) {
+	rows, err := q.db.Query(ctx, getOrphanedGuilds, orphanedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var guild_id string
+		if err := rows.Scan(&guild_id); err != nil {
+			return nil, err
+		}
+		items = append(items, guild_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPenaltyCategories = `-- name: GetPenaltyCategories :many
+SELECT id, guild_id, name, amount FROM penalty_category
+WHERE guild_id = $1
+ORDER BY name
+`
+
+func (q *Queries) GetPenaltyCategories(ctx context.Context, guildID string) ([]PenaltyCategory, error) {
+	rows, err := q.db.Query(ctx, getPenaltyCategories, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PenaltyCategory
+	for rows.Next() {
+		var i PenaltyCategory
+		if err := rows.Scan(
+			&i.ID,
+			&i.GuildID,
+			&i.Name,
+			&i.Amount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPenaltyCategory = `-- name: GetPenaltyCategory :one
+SELECT id, guild_id, name, amount FROM penalty_category
+WHERE guild_id = $1 AND id = $2 LIMIT 1
+`
+
+type GetPenaltyCategoryParams struct {
+	GuildID string
+	ID      int32
+}
+
+func (q *Queries) GetPenaltyCategory(ctx context.Context, arg GetPenaltyCategoryParams) (PenaltyCategory, error) {
+	row := q.db.QueryRow(ctx, getPenaltyCategory, arg.GuildID, arg.ID)
+	var i PenaltyCategory
+	err := row.Scan(
+		&i.ID,
+		&i.GuildID,
+		&i.Name,
+		&i.Amount,
+	)
+	return i, err
+}
+
+const getPendingPaymentForUpdate = `-- name: GetPendingPaymentForUpdate :one
+SELECT pending_payment.id, pending_payment.user_id, pending_payment.amount, pending_payment.created_at, player.id, player.discord_id, player.discord_name, player.guild_id, player.name, player.active FROM pending_payment
+JOIN player ON pending_payment.user_id = player.id
+WHERE pending_payment.id = $1 AND player.guild_id = $2 LIMIT 1
+FOR UPDATE OF pending_payment
+`
+
+type GetPendingPaymentForUpdateParams struct {
+	ID      int32
+	GuildID string
+}
+
+type GetPendingPaymentForUpdateRow struct {
+	PendingPayment PendingPayment
+	Player         Player
+}
+
+func (q *Queries) GetPendingPaymentForUpdate(ctx context.Context, arg GetPendingPaymentForUpdateParams) (GetPendingPaymentForUpdateRow, error) {
+	row := q.db.QueryRow(ctx, getPendingPaymentForUpdate, arg.ID, arg.GuildID)
+	var i GetPendingPaymentForUpdateRow
+	err := row.Scan(
+		&i.PendingPayment.ID,
+		&i.PendingPayment.UserID,
+		&i.PendingPayment.Amount,
+		&i.PendingPayment.CreatedAt,
+		&i.Player.ID,
+		&i.Player.DiscordID,
+		&i.Player.DiscordName,
+		&i.Player.GuildID,
+		&i.Player.Name,
+		&i.Player.Active,
+	)
+	return i, err
+}
+
+const getPendingPaymentSum = `-- name: GetPendingPaymentSum :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS pending_amount FROM pending_payment
+WHERE user_id = $1
+`
+
+func (q *Queries) GetPendingPaymentSum(ctx context.Context, userID int32) (int64, error) {
+	row := q.db.QueryRow(ctx, getPendingPaymentSum, userID)
+	var pending_amount int64
+	err := row.Scan(&pending_amount)
+	return pending_amount, err
+}
+
+const getPlayer = `-- name: GetPlayer :one
+SELECT player.id, player.discord_id, player.discord_name, player.guild_id, player.name, player.active, debt.id, debt.amount, debt.last_updated, debt.user_id FROM player
+JOIN debt ON player.id = debt.user_id
+WHERE player.discord_id = $1 AND player.guild_id = $2 LIMIT 1
+`
+
+type GetPlayerParams struct {
+	DiscordID string
+	GuildID   string
+}
+
+type GetPlayerRow struct {
+	Player Player
+	Debt   Debt
+}
+
+func (q *Queries) GetPlayer(ctx context.Context, arg GetPlayerParams) (GetPlayerRow, error) {
+	row := q.db.QueryRow(ctx, getPlayer, arg.DiscordID, arg.GuildID)
+	var i GetPlayerRow
+	err := row.Scan(
+		&i.Player.ID,
+		&i.Player.DiscordID,
+		&i.Player.DiscordName,
+		&i.Player.GuildID,
+		&i.Player.Name,
+		&i.Player.Active,
+		&i.Debt.ID,
+		&i.Debt.Amount,
+		&i.Debt.LastUpdated,
+		&i.Debt.UserID,
+	)
+	return i, err
+}
+
+const getPlayerForUpdate = `-- name: GetPlayerForUpdate :one
+SELECT player.id, player.discord_id, player.discord_name, player.guild_id, player.name, player.active, debt.id, debt.amount, debt.last_updated, debt.user_id FROM player
+JOIN debt ON player.id = debt.user_id
+WHERE player.discord_id = $1 AND player.guild_id = $2 LIMIT 1
+FOR UPDATE OF debt
+`
+
+type GetPlayerForUpdateParams struct {
+	DiscordID string
+	GuildID   string
+}
+
+type GetPlayerForUpdateRow struct {
+	Player Player
+	Debt   Debt
+}
+
+func (q *Queries) GetPlayerForUpdate(ctx context.Context, arg GetPlayerForUpdateParams) (GetPlayerForUpdateRow, error) {
+	row := q.db.QueryRow(ctx, getPlayerForUpdate, arg.DiscordID, arg.GuildID)
+	var i GetPlayerForUpdateRow
+	err := row.Scan(
+		&i.Player.ID,
 		&i.Player.DiscordID,
 		&i.Player.DiscordName,
 		&i.Player.GuildID,
 		&i.Player.Name,
+		&i.Player.Active,
 		&i.Debt.ID,
 		&i.Debt.Amount,
 		&i.Debt.LastUpdated,
@@ -296,6 +1082,82 @@ func (q *Queries) GetPlayer(ctx context.Context, arg GetPlayerParams) (GetPlayer
 	return i, err
 }
 
+const getPlayersToRemind = `-- name: GetPlayersToRemind :many
+SELECT player.id, player.discord_id, player.discord_name, player.guild_id, player.name, player.active, debt.id, debt.amount, debt.last_updated, debt.user_id FROM player
+JOIN debt ON player.id = debt.user_id
+WHERE player.guild_id = $1 AND player.active AND debt.amount > $2
+  AND NOT EXISTS (SELECT 1 FROM reminder_opt_out WHERE reminder_opt_out.user_id = player.id)
+  AND NOT EXISTS (SELECT 1 FROM reminder WHERE reminder.user_id = player.id AND reminder.due_at = $3)
+`
+
+type GetPlayersToRemindParams struct {
+	GuildID   string
+	Threshold int64
+	DueAt     pgtype.Timestamp
+}
+
+type GetPlayersToRemindRow struct {
+	Player Player
+	Debt   Debt
+}
+
+func (q *Queries) GetPlayersToRemind(ctx context.Context, arg GetPlayersToRemindParams) ([]GetPlayersToRemindRow, error) {
+	rows, err := q.db.Query(ctx, getPlayersToRemind, arg.GuildID, arg.Threshold, arg.DueAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPlayersToRemindRow
+	for rows.Next() {
+		var i GetPlayersToRemindRow
+		if err := rows.Scan(
+			&i.Player.ID,
+			&i.Player.DiscordID,
+			&i.Player.DiscordName,
+			&i.Player.GuildID,
+			&i.Player.Name,
+			&i.Player.Active,
+			&i.Debt.ID,
+			&i.Debt.Amount,
+			&i.Debt.LastUpdated,
+			&i.Debt.UserID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markGuildOrphaned = `-- name: MarkGuildOrphaned :exec
+INSERT INTO orphaned_guild (
+    guild_id
+) VALUES (
+    $1
+)
+ON CONFLICT (guild_id) DO NOTHING
+`
+
+func (q *Queries) MarkGuildOrphaned(ctx context.Context, guildID string) error {
+	_, err := q.db.Exec(ctx, markGuildOrphaned, guildID)
+	return err
+}
+
+const numberOfPenaltyCategories = `-- name: NumberOfPenaltyCategories :one
+SELECT COUNT(id) FROM penalty_category
+WHERE guild_id = $1
+`
+
+func (q *Queries) NumberOfPenaltyCategories(ctx context.Context, guildID string) (int64, error) {
+	row := q.db.QueryRow(ctx, numberOfPenaltyCategories, guildID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const numberOfPlayers = `-- name: NumberOfPlayers :one
 SELECT COUNT(discord_id) FROM player
 `
@@ -307,12 +1169,31 @@ func (q *Queries) NumberOfPlayers(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const putApiToken = `-- name: PutApiToken :exec
+INSERT INTO api_token (
+    guild_id, token_hash
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (guild_id) DO UPDATE SET token_hash = EXCLUDED.token_hash, created_at = now()
+`
+
+type PutApiTokenParams struct {
+	GuildID   string
+	TokenHash string
+}
+
+func (q *Queries) PutApiToken(ctx context.Context, arg PutApiTokenParams) error {
+	_, err := q.db.Exec(ctx, putApiToken, arg.GuildID, arg.TokenHash)
+	return err
+}
+
 const putBotSetup = `-- name: PutBotSetup :one
 INSERT INTO bot_setup (
     guild_id, channel_id, debts_message_id, registration_message_id
 ) VALUES (
     $1, $2, $3, $4
-) RETURNING guild_id, channel_id, registration_message_id, debts_message_id, created_at
+) RETURNING guild_id, channel_id, registration_message_id, debts_message_id, created_at, awaiting_reactions_until
 `
 
 type PutBotSetupParams struct {
@@ -336,10 +1217,100 @@ func (q *Queries) PutBotSetup(ctx context.Context, arg PutBotSetupParams) (BotSe
 		&i.RegistrationMessageID,
 		&i.DebtsMessageID,
 		&i.CreatedAt,
+		&i.AwaitingReactionsUntil,
+	)
+	return i, err
+}
+
+const putGuildSettings = `-- name: PutGuildSettings :one
+INSERT INTO guild_settings (
+    guild_id, penalty_amount, admin_role_id, registration_emoji, board_title, board_color, sort_order, locale,
+    treasurer_channel_id, reminder_weekday, reminder_time, reminder_threshold, timezone, summary_weekday,
+    summary_time
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+)
+ON CONFLICT (guild_id)
+DO UPDATE SET penalty_amount = $2, admin_role_id = $3, registration_emoji = $4, board_title = $5,
+    board_color = $6, sort_order = $7, locale = $8, treasurer_channel_id = $9, reminder_weekday = $10,
+    reminder_time = $11, reminder_threshold = $12, timezone = $13, summary_weekday = $14,
+    summary_time = $15, updated_at = now()
+RETURNING guild_id, penalty_amount, updated_at, admin_role_id, registration_emoji, board_title, board_color, sort_order, locale, treasurer_channel_id, reminder_weekday, reminder_time, reminder_threshold, timezone, summary_weekday, summary_time
+`
+
+type PutGuildSettingsParams struct {
+	GuildID            string
+	PenaltyAmount      int64
+	AdminRoleID        string
+	RegistrationEmoji  string
+	BoardTitle         string
+	BoardColor         int32
+	SortOrder          string
+	Locale             string
+	TreasurerChannelID string
+	ReminderWeekday    int32
+	ReminderTime       int32
+	ReminderThreshold  int64
+	Timezone           string
+	SummaryWeekday     int32
+	SummaryTime        int32
+}
+
+func (q *Queries) PutGuildSettings(ctx context.Context, arg PutGuildSettingsParams) (GuildSetting, error) {
+	row := q.db.QueryRow(ctx, putGuildSettings,
+		arg.GuildID,
+		arg.PenaltyAmount,
+		arg.AdminRoleID,
+		arg.RegistrationEmoji,
+		arg.BoardTitle,
+		arg.BoardColor,
+		arg.SortOrder,
+		arg.Locale,
+		arg.TreasurerChannelID,
+		arg.ReminderWeekday,
+		arg.ReminderTime,
+		arg.ReminderThreshold,
+		arg.Timezone,
+		arg.SummaryWeekday,
+		arg.SummaryTime,
+	)
+	var i GuildSetting
+	err := row.Scan(
+		&i.GuildID,
+		&i.PenaltyAmount,
+		&i.UpdatedAt,
+		&i.AdminRoleID,
+		&i.RegistrationEmoji,
+		&i.BoardTitle,
+		&i.BoardColor,
+		&i.SortOrder,
+		&i.Locale,
+		&i.TreasurerChannelID,
+		&i.ReminderWeekday,
+		&i.ReminderTime,
+		&i.ReminderThreshold,
+		&i.Timezone,
+		&i.SummaryWeekday,
+		&i.SummaryTime,
 	)
 	return i, err
 }
 
+const setBotSetupAwaitingReactions = `-- name: SetBotSetupAwaitingReactions :exec
+UPDATE bot_setup SET awaiting_reactions_until = $2
+WHERE guild_id = $1
+`
+
+type SetBotSetupAwaitingReactionsParams struct {
+	GuildID                string
+	AwaitingReactionsUntil pgtype.Timestamp
+}
+
+func (q *Queries) SetBotSetupAwaitingReactions(ctx context.Context, arg SetBotSetupAwaitingReactionsParams) error {
+	_, err := q.db.Exec(ctx, setBotSetupAwaitingReactions, arg.GuildID, arg.AwaitingReactionsUntil)
+	return err
+}
+
 const setDebt = `-- name: SetDebt :exec
 INSERT INTO debt (amount, user_id)
 VALUES ($1, $2)
@@ -358,11 +1329,52 @@ func (q *Queries) SetDebt(ctx context.Context, arg SetDebtParams) error {
 	return err
 }
 
+const setPlayerActive = `-- name: SetPlayerActive :exec
+UPDATE player SET active = $2
+WHERE id = $1
+`
+
+type SetPlayerActiveParams struct {
+	ID     int32
+	Active bool
+}
+
+func (q *Queries) SetPlayerActive(ctx context.Context, arg SetPlayerActiveParams) error {
+	_, err := q.db.Exec(ctx, setPlayerActive, arg.ID, arg.Active)
+	return err
+}
+
+const unmarkGuildOrphaned = `-- name: UnmarkGuildOrphaned :exec
+DELETE FROM orphaned_guild
+WHERE guild_id = $1
+`
+
+func (q *Queries) UnmarkGuildOrphaned(ctx context.Context, guildID string) error {
+	_, err := q.db.Exec(ctx, unmarkGuildOrphaned, guildID)
+	return err
+}
+
+const updateBotSetupMessages = `-- name: UpdateBotSetupMessages :exec
+UPDATE bot_setup SET registration_message_id = $2, debts_message_id = $3
+WHERE guild_id = $1
+`
+
+type UpdateBotSetupMessagesParams struct {
+	GuildID               string
+	RegistrationMessageID string
+	DebtsMessageID        string
+}
+
+func (q *Queries) UpdateBotSetupMessages(ctx context.Context, arg UpdateBotSetupMessagesParams) error {
+	_, err := q.db.Exec(ctx, updateBotSetupMessages, arg.GuildID, arg.RegistrationMessageID, arg.DebtsMessageID)
+	return err
+}
+
 const updateJournalEntry = `-- name: UpdateJournalEntry :one
 UPDATE debt_journal
 SET amount = $1, description = $2
 WHERE id = $3
-RETURNING id, amount, description, date, user_id
+RETURNING id, amount, description, date, user_id, category
 `
 
 type UpdateJournalEntryParams struct {
@@ -380,6 +1392,32 @@ func (q *Queries) UpdateJournalEntry(ctx context.Context, arg UpdateJournalEntry
 		&i.Description,
 		&i.Date,
 		&i.UserID,
+		&i.Category,
 	)
 	return i, err
 }
+
+const updatePlayerName = `-- name: UpdatePlayerName :execrows
+UPDATE player SET discord_name = $3, name = $4
+WHERE discord_id = $1 AND guild_id = $2 AND (discord_name <> $3 OR name <> $4)
+`
+
+type UpdatePlayerNameParams struct {
+	DiscordID   string
+	GuildID     string
+	DiscordName string
+	Name        string
+}
+
+func (q *Queries) UpdatePlayerName(ctx context.Context, arg UpdatePlayerNameParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updatePlayerName,
+		arg.DiscordID,
+		arg.GuildID,
+		arg.DiscordName,
+		arg.Name,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
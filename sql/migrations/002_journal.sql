@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+DROP TRIGGER "check_number_of_journal_rows" ON "debt_journal";
+DROP FUNCTION "check_number_of_journal_rows"();
+CREATE INDEX "debt_journal_user_id_date_idx" ON "debt_journal" ("user_id", "date");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX "debt_journal_user_id_date_idx";
+CREATE FUNCTION "check_number_of_journal_rows" () RETURNS trigger LANGUAGE plpgsql AS $$
+BEGIN
+    IF (SELECT COUNT(*) FROM debt_journal WHERE user_id = NEW.user_id) > 9 THEN
+        DELETE FROM debt_journal WHERE (user_id, date) in
+                                       (SELECT user_id, min(date) FROM debt_journal WHERE user_id = NEW.user_id GROUP BY user_id);
+    END IF;
+    RETURN NEW;
+END;
+$$;
+CREATE TRIGGER "check_number_of_journal_rows" BEFORE INSERT ON "debt_journal" FOR EACH ROW EXECUTE FUNCTION "check_number_of_journal_rows"();
+-- +goose StatementEnd
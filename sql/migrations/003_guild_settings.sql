@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "guild_settings" (
+                                  "guild_id" text NOT NULL,
+                                  "penalty_amount" bigint NOT NULL DEFAULT 10000,
+                                  "updated_at" timestamp NOT NULL DEFAULT now(),
+                                  PRIMARY KEY ("guild_id")
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "guild_settings";
+-- +goose StatementEnd
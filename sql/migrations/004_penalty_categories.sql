@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "penalty_category" (
+                                    "id" serial NOT NULL,
+                                    "guild_id" text NOT NULL,
+                                    "name" character varying(100) NOT NULL,
+                                    "amount" bigint NOT NULL,
+                                    PRIMARY KEY ("id"),
+                                    UNIQUE ("guild_id", "name")
+);
+ALTER TABLE "debt_journal" ADD COLUMN "category" text NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "debt_journal" DROP COLUMN "category";
+DROP TABLE "penalty_category";
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "guild_settings" ADD COLUMN "admin_role_id" text NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "guild_settings" DROP COLUMN "admin_role_id";
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "orphaned_guild"
+(
+    "guild_id" TEXT PRIMARY KEY,
+    "orphaned_at" TIMESTAMP NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "orphaned_guild";
+-- +goose StatementEnd
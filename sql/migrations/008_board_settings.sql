@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "guild_settings" ADD COLUMN "registration_emoji" text NOT NULL DEFAULT '💰';
+ALTER TABLE "guild_settings" ADD COLUMN "board_title" text NOT NULL DEFAULT ':moneybag: {amount} in die Gildenbank!';
+ALTER TABLE "guild_settings" ADD COLUMN "board_color" integer NOT NULL DEFAULT 15844367;
+ALTER TABLE "guild_settings" ADD COLUMN "sort_order" text NOT NULL DEFAULT 'name';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "guild_settings" DROP COLUMN "sort_order";
+ALTER TABLE "guild_settings" DROP COLUMN "board_color";
+ALTER TABLE "guild_settings" DROP COLUMN "board_title";
+ALTER TABLE "guild_settings" DROP COLUMN "registration_emoji";
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "guild_settings" ADD COLUMN "locale" text NOT NULL DEFAULT '';
+ALTER TABLE "guild_settings" ALTER COLUMN "board_title" SET DEFAULT '';
+UPDATE "guild_settings" SET "board_title" = '' WHERE "board_title" = ':moneybag: {amount} in die Gildenbank!';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE "guild_settings" SET "board_title" = ':moneybag: {amount} in die Gildenbank!' WHERE "board_title" = '';
+ALTER TABLE "guild_settings" ALTER COLUMN "board_title" SET DEFAULT ':moneybag: {amount} in die Gildenbank!';
+ALTER TABLE "guild_settings" DROP COLUMN "locale";
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "pending_payment"
+(
+    "id" SERIAL PRIMARY KEY,
+    "user_id" INTEGER NOT NULL REFERENCES "player" ("id") ON DELETE CASCADE,
+    "amount" BIGINT NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT now()
+);
+ALTER TABLE "guild_settings" ADD COLUMN "treasurer_channel_id" text NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "guild_settings" DROP COLUMN "treasurer_channel_id";
+DROP TABLE "pending_payment";
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "guild_settings" ADD COLUMN "reminder_weekday" INTEGER NOT NULL DEFAULT -1;
+ALTER TABLE "guild_settings" ADD COLUMN "reminder_time" INTEGER NOT NULL DEFAULT 1140;
+ALTER TABLE "guild_settings" ADD COLUMN "reminder_threshold" BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE "guild_settings" ADD COLUMN "timezone" text NOT NULL DEFAULT 'UTC';
+CREATE TABLE "reminder_opt_out"
+(
+    "user_id" INTEGER PRIMARY KEY REFERENCES "player" ("id") ON DELETE CASCADE,
+    "created_at" TIMESTAMP NOT NULL DEFAULT now()
+);
+CREATE TABLE "reminder"
+(
+    "user_id" INTEGER NOT NULL REFERENCES "player" ("id") ON DELETE CASCADE,
+    "due_at" TIMESTAMP NOT NULL,
+    "sent_at" TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY ("user_id", "due_at")
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "reminder";
+DROP TABLE "reminder_opt_out";
+ALTER TABLE "guild_settings" DROP COLUMN "timezone";
+ALTER TABLE "guild_settings" DROP COLUMN "reminder_threshold";
+ALTER TABLE "guild_settings" DROP COLUMN "reminder_time";
+ALTER TABLE "guild_settings" DROP COLUMN "reminder_weekday";
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "guild_settings" ADD COLUMN "summary_weekday" INTEGER NOT NULL DEFAULT -1;
+ALTER TABLE "guild_settings" ADD COLUMN "summary_time" INTEGER NOT NULL DEFAULT 1140;
+CREATE TABLE "weekly_summary"
+(
+    "guild_id" text NOT NULL,
+    "due_at" TIMESTAMP NOT NULL,
+    "posted_at" TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY ("guild_id", "due_at")
+);
+CREATE INDEX "debt_journal_date_idx" ON "debt_journal" ("date");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX "debt_journal_date_idx";
+DROP TABLE "weekly_summary";
+ALTER TABLE "guild_settings" DROP COLUMN "summary_time";
+ALTER TABLE "guild_settings" DROP COLUMN "summary_weekday";
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "api_token"
+(
+    "guild_id" text PRIMARY KEY,
+    "token_hash" text NOT NULL UNIQUE,
+    "created_at" TIMESTAMP NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "api_token";
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "bot_setup" ADD COLUMN "awaiting_reactions" BOOLEAN NOT NULL DEFAULT false;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "bot_setup" DROP COLUMN "awaiting_reactions";
+-- +goose StatementEnd
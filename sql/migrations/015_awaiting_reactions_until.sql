@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "bot_setup" ADD COLUMN "awaiting_reactions_until" TIMESTAMP;
+UPDATE "bot_setup" SET "awaiting_reactions_until" = now() + interval '7 days' WHERE "awaiting_reactions";
+ALTER TABLE "bot_setup" DROP COLUMN "awaiting_reactions";
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "bot_setup" ADD COLUMN "awaiting_reactions" BOOLEAN NOT NULL DEFAULT false;
+UPDATE "bot_setup" SET "awaiting_reactions" = true WHERE "awaiting_reactions_until" > now();
+ALTER TABLE "bot_setup" DROP COLUMN "awaiting_reactions_until";
+-- +goose StatementEnd
@@ -5,11 +5,16 @@ ON CONFLICT (user_id)
 DO UPDATE SET amount = $1, last_updated = now()
 WHERE debt.user_id = $2;
 
+-- name: AddToDebt :one
+UPDATE debt SET amount = amount + @amount, last_updated = now()
+WHERE user_id = @user_id
+RETURNING amount;
+
 -- name: AddJournalEntry :one
 INSERT INTO debt_journal (
-    amount, description, user_id
+    amount, description, user_id, category
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4
 ) RETURNING *;
 
 -- name: UpdateJournalEntry :one
@@ -24,7 +29,30 @@ WHERE id = $1;
 
 -- name: GetJournalEntries :many
 SELECT * FROM debt_journal
-WHERE user_id = $1;
+WHERE user_id = $1
+ORDER BY date DESC, id DESC;
+
+-- name: GetJournalEntriesOfGuild :many
+SELECT sqlc.embed(debt_journal), player.discord_id, player.name FROM debt_journal
+JOIN player ON player.id = debt_journal.user_id
+WHERE player.guild_id = @guild_id
+  AND (@category::text = '' OR debt_journal.category = @category::text)
+ORDER BY debt_journal.date DESC, debt_journal.id DESC
+LIMIT @lim OFFSET @off;
+
+-- name: GetJournalEntriesOfGuildBetween :many
+SELECT sqlc.embed(debt_journal), player.discord_id, player.name FROM debt_journal
+JOIN player ON player.id = debt_journal.user_id
+WHERE player.guild_id = @guild_id AND debt_journal.date >= @date_from AND debt_journal.date < @date_to
+ORDER BY debt_journal.date, debt_journal.id;
+
+-- name: GetJournalEntriesOfPlayer :many
+SELECT sqlc.embed(debt_journal), player.discord_id, player.name FROM debt_journal
+JOIN player ON player.id = debt_journal.user_id
+WHERE player.guild_id = @guild_id AND player.discord_id = @discord_id
+  AND (@category::text = '' OR debt_journal.category = @category::text)
+ORDER BY debt_journal.date DESC, debt_journal.id DESC
+LIMIT @lim OFFSET @off;
 
 -- name: DoesPlayerExist :one
 SELECT EXISTS(SELECT 1 FROM player WHERE discord_id = $1 AND guild_id = $2);
@@ -41,15 +69,42 @@ SELECT sqlc.embed(player), sqlc.embed(debt) FROM player
 JOIN debt ON player.id = debt.user_id
 WHERE player.discord_id = $1 AND player.guild_id = $2 LIMIT 1;
 
--- name: GetAllPlayers :many
+-- name: GetPlayerForUpdate :one
 SELECT sqlc.embed(player), sqlc.embed(debt) FROM player
 JOIN debt ON player.id = debt.user_id
+WHERE player.discord_id = $1 AND player.guild_id = $2 LIMIT 1
+FOR UPDATE OF debt;
+
+-- name: GetAllPlayers :many
+SELECT sqlc.embed(player), sqlc.embed(debt),
+    (SELECT COALESCE(SUM(amount), 0) FROM pending_payment WHERE pending_payment.user_id = player.id)::bigint AS pending_amount
+FROM player
+JOIN debt ON player.id = debt.user_id
+WHERE player.guild_id = $1 AND player.active;
+
+-- name: GetAllPlayersWithInactive :many
+SELECT sqlc.embed(player), sqlc.embed(debt),
+    (SELECT COALESCE(SUM(amount), 0) FROM pending_payment WHERE pending_payment.user_id = player.id)::bigint AS pending_amount
+FROM player
+JOIN debt ON player.id = debt.user_id
 WHERE player.guild_id = $1;
 
+-- name: SetPlayerActive :exec
+UPDATE player SET active = $2
+WHERE id = $1;
+
+-- name: UpdatePlayerName :execrows
+UPDATE player SET discord_name = $3, name = $4
+WHERE discord_id = $1 AND guild_id = $2 AND (discord_name <> $3 OR name <> $4);
+
 -- name: DeletePlayer :exec
 DELETE FROM player
 WHERE id = $1;
 
+-- name: DeletePlayersOfGuild :exec
+DELETE FROM player
+WHERE guild_id = $1;
+
 -- name: NumberOfPlayers :one
 SELECT COUNT(discord_id) FROM player;
 
@@ -64,6 +119,14 @@ INSERT INTO bot_setup (
     $1, $2, $3, $4
 ) RETURNING *;
 
+-- name: UpdateBotSetupMessages :exec
+UPDATE bot_setup SET registration_message_id = $2, debts_message_id = $3
+WHERE guild_id = $1;
+
+-- name: SetBotSetupAwaitingReactions :exec
+UPDATE bot_setup SET awaiting_reactions_until = $2
+WHERE guild_id = $1;
+
 -- name: DeleteBotSetup :exec
 DELETE FROM bot_setup
 WHERE guild_id = $1;
@@ -76,4 +139,166 @@ WHERE bot_setup.guild_id = $1 LIMIT 1;
 SELECT EXISTS(SELECT 1 FROM bot_setup WHERE guild_id = $1);
 
 -- name: GetAllBotSetups :many
-SELECT * FROM bot_setup;
\ No newline at end of file
+SELECT * FROM bot_setup;
+
+-- name: GetGuildSettings :one
+SELECT * FROM guild_settings
+WHERE guild_id = $1 LIMIT 1;
+
+-- name: DoGuildSettingsExist :one
+SELECT EXISTS(SELECT 1 FROM guild_settings WHERE guild_id = $1);
+
+-- name: GetGuildSettingsWithReminders :many
+SELECT * FROM guild_settings
+WHERE reminder_weekday >= 0;
+
+-- name: GetGuildSettingsWithSummaries :many
+SELECT * FROM guild_settings
+WHERE summary_weekday >= 0;
+
+-- name: PutGuildSettings :one
+INSERT INTO guild_settings (
+    guild_id, penalty_amount, admin_role_id, registration_emoji, board_title, board_color, sort_order, locale,
+    treasurer_channel_id, reminder_weekday, reminder_time, reminder_threshold, timezone, summary_weekday,
+    summary_time
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+)
+ON CONFLICT (guild_id)
+DO UPDATE SET penalty_amount = $2, admin_role_id = $3, registration_emoji = $4, board_title = $5,
+    board_color = $6, sort_order = $7, locale = $8, treasurer_channel_id = $9, reminder_weekday = $10,
+    reminder_time = $11, reminder_threshold = $12, timezone = $13, summary_weekday = $14,
+    summary_time = $15, updated_at = now()
+RETURNING *;
+
+-- name: DeleteGuildSettings :exec
+DELETE FROM guild_settings
+WHERE guild_id = $1;
+
+-- name: GetPenaltyCategories :many
+SELECT * FROM penalty_category
+WHERE guild_id = $1
+ORDER BY name;
+
+-- name: GetPenaltyCategory :one
+SELECT * FROM penalty_category
+WHERE guild_id = $1 AND id = $2 LIMIT 1;
+
+-- name: DoesPenaltyCategoryExist :one
+SELECT EXISTS(SELECT 1 FROM penalty_category WHERE guild_id = $1 AND name = $2);
+
+-- name: NumberOfPenaltyCategories :one
+SELECT COUNT(id) FROM penalty_category
+WHERE guild_id = $1;
+
+-- name: AddPenaltyCategory :one
+INSERT INTO penalty_category (
+    guild_id, name, amount
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: DeletePenaltyCategory :exec
+DELETE FROM penalty_category
+WHERE guild_id = $1 AND name = $2;
+
+-- name: MarkGuildOrphaned :exec
+INSERT INTO orphaned_guild (
+    guild_id
+) VALUES (
+    $1
+)
+ON CONFLICT (guild_id) DO NOTHING;
+
+-- name: UnmarkGuildOrphaned :exec
+DELETE FROM orphaned_guild
+WHERE guild_id = $1;
+
+-- name: GetOrphanedGuilds :many
+SELECT guild_id FROM orphaned_guild
+WHERE orphaned_at < $1;
+
+-- name: AddPendingPayment :one
+INSERT INTO pending_payment (
+    user_id, amount
+) VALUES (
+    $1, $2
+) RETURNING *;
+
+-- name: GetPendingPaymentSum :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS pending_amount FROM pending_payment
+WHERE user_id = $1;
+
+-- name: GetPendingPaymentForUpdate :one
+SELECT sqlc.embed(pending_payment), sqlc.embed(player) FROM pending_payment
+JOIN player ON pending_payment.user_id = player.id
+WHERE pending_payment.id = $1 AND player.guild_id = $2 LIMIT 1
+FOR UPDATE OF pending_payment;
+
+-- name: DeletePendingPayment :exec
+DELETE FROM pending_payment
+WHERE id = $1;
+
+-- name: GetPlayersToRemind :many
+SELECT sqlc.embed(player), sqlc.embed(debt) FROM player
+JOIN debt ON player.id = debt.user_id
+WHERE player.guild_id = @guild_id AND player.active AND debt.amount > @threshold
+  AND NOT EXISTS (SELECT 1 FROM reminder_opt_out WHERE reminder_opt_out.user_id = player.id)
+  AND NOT EXISTS (SELECT 1 FROM reminder WHERE reminder.user_id = player.id AND reminder.due_at = @due_at);
+
+-- name: AddReminder :execrows
+INSERT INTO reminder (
+    user_id, due_at
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (user_id, due_at) DO NOTHING;
+
+-- name: DeleteRemindersBefore :exec
+DELETE FROM reminder
+USING player
+WHERE reminder.user_id = player.id AND player.guild_id = $1 AND reminder.due_at < $2;
+
+-- name: AddReminderOptOut :exec
+INSERT INTO reminder_opt_out (
+    user_id
+) VALUES (
+    $1
+)
+ON CONFLICT (user_id) DO NOTHING;
+
+-- name: DeleteReminderOptOut :exec
+DELETE FROM reminder_opt_out
+WHERE user_id = $1;
+
+-- name: AddWeeklySummary :execrows
+INSERT INTO weekly_summary (
+    guild_id, due_at
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (guild_id, due_at) DO NOTHING;
+
+-- name: DeleteWeeklySummariesBefore :exec
+DELETE FROM weekly_summary
+WHERE guild_id = $1 AND due_at < $2;
+
+-- name: DeleteWeeklySummariesOfGuild :exec
+DELETE FROM weekly_summary
+WHERE guild_id = $1;
+
+-- name: PutApiToken :exec
+INSERT INTO api_token (
+    guild_id, token_hash
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (guild_id) DO UPDATE SET token_hash = EXCLUDED.token_hash, created_at = now();
+
+-- name: GetGuildOfApiToken :one
+SELECT guild_id FROM api_token
+WHERE token_hash = $1;
+
+-- name: DeleteApiToken :execrows
+DELETE FROM api_token
+WHERE guild_id = $1;
@@ -5,6 +5,7 @@ CREATE TABLE player
     discord_name TEXT NOT NULL,
     guild_id TEXT NOT NULL,
     name varchar(255) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT true,
     UNIQUE (discord_id, guild_id)
 );
 
@@ -22,33 +23,94 @@ CREATE TABLE debt_journal
     amount BIGINT NOT NULL,
     description TEXT NOT NULL DEFAULT '',
     date TIMESTAMP NOT NULL DEFAULT now(),
-    user_id INTEGER NOT NULL REFERENCES player(id) ON DELETE CASCADE
+    user_id INTEGER NOT NULL REFERENCES player(id) ON DELETE CASCADE,
+    category TEXT NOT NULL DEFAULT ''
 );
 
+CREATE INDEX debt_journal_user_id_date_idx ON debt_journal (user_id, date);
+CREATE INDEX debt_journal_date_idx ON debt_journal (date);
+
 CREATE TABLE bot_setup
 (
     guild_id TEXT UNIQUE NOT NULL,
     channel_id TEXT NOT NULL,
     registration_message_id TEXT NOT NULL,
     debts_message_id TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    awaiting_reactions_until TIMESTAMP
+);
+
+CREATE TABLE guild_settings
+(
+    guild_id TEXT PRIMARY KEY,
+    penalty_amount BIGINT NOT NULL DEFAULT 10000,
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    admin_role_id TEXT NOT NULL DEFAULT '',
+    registration_emoji TEXT NOT NULL DEFAULT '💰',
+    board_title TEXT NOT NULL DEFAULT '',
+    board_color INTEGER NOT NULL DEFAULT 15844367,
+    sort_order TEXT NOT NULL DEFAULT 'name',
+    locale TEXT NOT NULL DEFAULT '',
+    treasurer_channel_id TEXT NOT NULL DEFAULT '',
+    reminder_weekday INTEGER NOT NULL DEFAULT -1,
+    reminder_time INTEGER NOT NULL DEFAULT 1140,
+    reminder_threshold BIGINT NOT NULL DEFAULT 0,
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    summary_weekday INTEGER NOT NULL DEFAULT -1,
+    summary_time INTEGER NOT NULL DEFAULT 1140
+);
+
+CREATE TABLE penalty_category
+(
+    id SERIAL PRIMARY KEY,
+    guild_id TEXT NOT NULL,
+    name varchar(100) NOT NULL,
+    amount BIGINT NOT NULL,
+    UNIQUE (guild_id, name)
+);
+
+CREATE TABLE pending_payment
+(
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES player(id) ON DELETE CASCADE,
+    amount BIGINT NOT NULL,
     created_at TIMESTAMP NOT NULL DEFAULT now()
 );
 
-CREATE OR REPLACE FUNCTION check_number_of_journal_rows()
-RETURNS TRIGGER AS 
-$$
-BEGIN
-    IF (SELECT COUNT(*) FROM debt_journal WHERE user_id = NEW.user_id) > 9 THEN
-        DELETE FROM debt_journal WHERE user_id in (SELECT user_id FROM debt_journal WHERE user_id = NEW.user_id ORDER BY date ASC LIMIT 1 AND guild_id = NEW.guild_id);
-    END IF;
-    RETURN NEW;
-END;
-$$
-LANGUAGE plpgsql;
+CREATE TABLE reminder_opt_out
+(
+    user_id INTEGER PRIMARY KEY REFERENCES player(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE TABLE reminder
+(
+    user_id INTEGER NOT NULL REFERENCES player(id) ON DELETE CASCADE,
+    due_at TIMESTAMP NOT NULL,
+    sent_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, due_at)
+);
+
+CREATE TABLE weekly_summary
+(
+    guild_id TEXT NOT NULL,
+    due_at TIMESTAMP NOT NULL,
+    posted_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (guild_id, due_at)
+);
 
-CREATE TRIGGER check_number_of_journal_rows
-BEFORE INSERT ON debt_journal 
-FOR EACH ROW EXECUTE FUNCTION check_number_of_journal_rows();
+CREATE TABLE api_token
+(
+    guild_id TEXT PRIMARY KEY,
+    token_hash TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE TABLE orphaned_guild
+(
+    guild_id TEXT PRIMARY KEY,
+    orphaned_at TIMESTAMP NOT NULL DEFAULT now()
+);
 
 CREATE OR REPLACE FUNCTION create_debt_for_new_player()
 RETURNS TRIGGER AS